@@ -0,0 +1,416 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"superfast-copy-util/copier"
+	"superfast-copy-util/logging"
+	"superfast-copy-util/scanner"
+)
+
+// JobState is the lifecycle stage of a job
+type JobState string
+
+const (
+	StateScanning JobState = "scanning"
+	StateCopying  JobState = "copying"
+	StatePaused   JobState = "paused"
+	StateDone     JobState = "done"
+	StateFailed   JobState = "failed" // 일부 파일 실패
+	StateCanceled JobState = "canceled"
+)
+
+const maxJobErrors = 100 // 작업별로 보관하는 오류 메시지 수
+
+// JobRequest describes a copy job; Options uses the CLI flag names without
+// the leading dash (e.g. {"schedule": "two-lane", "verify": "true"})
+type JobRequest struct {
+	Source  string            `json:"source"`
+	Target  string            `json:"target"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// JobSetup is what a Builder prepares for a job
+type JobSetup struct {
+	Copier  *copier.Copier
+	Scanner *scanner.Scanner // nil 이면 기본 스캐너
+	Stream  bool             // 스캔과 동시에 복사 (파이프라인 모드: 사전 점검/이름 충돌 계획 생략)
+
+	Preflight copier.PreflightPolicy // 목록 모드에서 복사 전 대상 여유 공간 점검
+}
+
+// Builder turns a request into a configured copier and scanner
+type Builder func(req JobRequest) (JobSetup, error)
+
+// Event is pushed to progress subscribers
+type Event struct {
+	Type string `json:"type"` // scan, copy, error, state
+	Data any    `json:"data"`
+}
+
+// JobStatus is the JSON view of a job
+type JobStatus struct {
+	ID         string              `json:"id"`
+	Request    JobRequest          `json:"request"`
+	State      JobState            `json:"state"`
+	Created    time.Time           `json:"created"`
+	Finished   *time.Time          `json:"finished,omitempty"`
+	Scan       scanner.Progress    `json:"scan"`
+	Copy       copier.CopyProgress `json:"copy"`
+	ErrorCount int64               `json:"errorCount"`
+	Errors     []string            `json:"errors,omitempty"`
+}
+
+// Job is one running or finished copy
+type Job struct {
+	id        string
+	req       JobRequest
+	created   time.Time
+	scanner   *scanner.Scanner
+	copier    *copier.Copier
+	stream    bool
+	preflight copier.PreflightPolicy
+	log       *slog.Logger
+
+	mu       sync.Mutex
+	state    JobState
+	canceled bool
+	finished time.Time
+	scan     scanner.Progress
+	copy     copier.CopyProgress
+	errCount int64
+	errs     []string
+	subs     map[chan Event]struct{}
+	doneCh   chan struct{}
+}
+
+// Manager keeps every job submitted to the daemon
+type Manager struct {
+	build Builder
+	log   *slog.Logger
+	mu    sync.Mutex
+	jobs  map[string]*Job
+	seq   int
+}
+
+// NewManager creates a job manager using build to configure copiers
+func NewManager(build Builder) *Manager {
+	return &Manager{build: build, log: logging.Discard(), jobs: make(map[string]*Job)}
+}
+
+// SetLogger logs job lifecycle events to l; each job's scanner and copier
+// log through it with a "job" attribute
+func (m *Manager) SetLogger(l *slog.Logger) {
+	if l != nil {
+		m.log = l
+	}
+}
+
+// ErrNotFound is returned for unknown job IDs
+var ErrNotFound = errors.New("작업을 찾을 수 없음")
+
+// Submit validates the request and starts the job immediately
+func (m *Manager) Submit(req JobRequest) (*Job, error) {
+	if req.Source == "" || req.Target == "" {
+		return nil, errors.New("source 와 target 은 필수입니다")
+	}
+	setup, err := m.build(req)
+	if err != nil {
+		return nil, err
+	}
+	if setup.Scanner == nil {
+		setup.Scanner = scanner.NewScanner()
+	}
+	m.mu.Lock()
+	m.seq++
+	j := &Job{
+		id:        fmt.Sprintf("%d", m.seq),
+		req:       req,
+		created:   time.Now(),
+		scanner:   setup.Scanner,
+		copier:    setup.Copier,
+		stream:    setup.Stream,
+		preflight: setup.Preflight,
+		state:     StateScanning,
+		subs:      make(map[chan Event]struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	m.jobs[j.id] = j
+	m.mu.Unlock()
+	j.log = m.log.With("job", j.id)
+	j.scanner.SetLogger(j.log)
+	j.copier.SetLogger(j.log)
+	j.log.Info("작업 제출", "source", req.Source, "target", req.Target, "options", req.Options)
+	go j.run()
+	return j, nil
+}
+
+// Get returns a job by ID
+func (m *Manager) Get(id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return j, nil
+}
+
+// List returns every job, oldest first
+func (m *Manager) List() []*Job {
+	m.mu.Lock()
+	jobs := make([]*Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		jobs = append(jobs, j)
+	}
+	m.mu.Unlock()
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].created.Before(jobs[b].created) })
+	return jobs
+}
+
+// ID returns the job identifier
+func (j *Job) ID() string { return j.id }
+
+// Done is closed when the job has finished
+func (j *Job) Done() <-chan struct{} { return j.doneCh }
+
+// Status returns a snapshot of the job
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	st := JobStatus{
+		ID:         j.id,
+		Request:    j.req,
+		State:      j.state,
+		Created:    j.created,
+		Scan:       j.scan,
+		Copy:       j.copy,
+		ErrorCount: j.errCount,
+		Errors:     append([]string(nil), j.errs...),
+	}
+	if !j.finished.IsZero() {
+		t := j.finished
+		st.Finished = &t
+	}
+	return st
+}
+
+// Cancel stops scanning and copying
+func (j *Job) Cancel() {
+	j.mu.Lock()
+	if j.finished.IsZero() {
+		j.canceled = true
+	}
+	j.mu.Unlock()
+	j.scanner.Cancel()
+	j.copier.Cancel()
+}
+
+// Pause parks the copy workers
+func (j *Job) Pause() error { return j.setPaused(true) }
+
+// Resume continues a paused job
+func (j *Job) Resume() error { return j.setPaused(false) }
+
+func (j *Job) setPaused(pause bool) error {
+	j.mu.Lock()
+	if !j.finished.IsZero() {
+		j.mu.Unlock()
+		return errors.New("이미 끝난 작업입니다")
+	}
+	j.mu.Unlock()
+	if pause {
+		j.copier.Pause()
+	} else {
+		j.copier.Resume()
+	}
+	j.mu.Lock()
+	if j.state == StateCopying || j.state == StatePaused {
+		j.state = StateCopying
+		if pause {
+			j.state = StatePaused
+		}
+	}
+	j.mu.Unlock()
+	j.publish(Event{Type: "state", Data: j.Status()})
+	return nil
+}
+
+// Subscribe returns a channel of progress events; it is closed when the job
+// ends or unsubscribe is called. Slow subscribers miss intermediate updates.
+func (j *Job) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 64)
+	j.mu.Lock()
+	if !j.finished.IsZero() {
+		j.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	j.subs[ch] = struct{}{}
+	j.mu.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			j.mu.Lock()
+			if _, ok := j.subs[ch]; ok {
+				delete(j.subs, ch)
+				close(ch)
+			}
+			j.mu.Unlock()
+		})
+	}
+}
+
+// publish fans an event out to subscribers without blocking the job
+func (j *Job) publish(ev Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for ch := range j.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func (j *Job) recordError(err error) {
+	j.mu.Lock()
+	j.errCount++
+	if len(j.errs) < maxJobErrors {
+		j.errs = append(j.errs, err.Error())
+	}
+	j.mu.Unlock()
+	j.publish(Event{Type: "error", Data: err.Error()})
+}
+
+func (j *Job) setState(s JobState) {
+	j.mu.Lock()
+	j.state = s
+	j.mu.Unlock()
+	j.publish(Event{Type: "state", Data: j.Status()})
+}
+
+// run drives scanner and copier through their progress channels, the same
+// way the CLI does
+func (j *Job) run() {
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		for p := range j.scanner.Progress() {
+			j.mu.Lock()
+			j.scan = p
+			j.mu.Unlock()
+			j.publish(Event{Type: "scan", Data: p})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for err := range j.scanner.Errors() {
+			j.recordError(fmt.Errorf("스캔: %v", err))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for p := range j.copier.Progress() {
+			j.mu.Lock()
+			j.copy = p
+			j.mu.Unlock()
+			j.publish(Event{Type: "copy", Data: p})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for err := range j.copier.Errors() {
+			j.recordError(fmt.Errorf("복사: %v", err))
+		}
+	}()
+
+	j.scanner.ScanDirectory(j.req.Source)
+	failed := 0
+	if j.stream {
+		// 목록 없이 복사하므로 이름 충돌은 도착 순서대로 처리됨
+		j.setState(StateCopying)
+		j.copier.CopyFromScanner(j.scanner.Files())
+	} else {
+		var files []string
+		var totalSize int64
+		for f := range j.scanner.Files() {
+			files = append(files, f.Path)
+			totalSize += f.Size
+		}
+		j.copier.SetTotal(int64(len(files)), totalSize)
+		// CLI 와 같이 겹치는 이름을 경로 순서로 미리 정하고 여유 공간 점검
+		j.copier.PlanNames(files)
+		if len(files) > 0 && !j.preflightOK(files) {
+			// 빈 목록으로 시작해 copier 채널을 정리
+			files = nil
+			failed++
+		}
+		j.setState(StateCopying)
+		j.copier.CopyFilesParallel(files)
+	}
+	for result := range j.copier.Results() {
+		if !result.Success {
+			failed++
+			j.recordError(fmt.Errorf("%s: %v", result.FilePath, result.Error))
+		}
+	}
+	wg.Wait()
+	j.finish(failed)
+}
+
+// preflightOK checks free space and inodes on the targets; insufficient
+// targets are recorded as errors and false means the policy refuses the copy
+func (j *Job) preflightOK(files []string) bool {
+	if j.preflight == copier.PreflightOff {
+		return true
+	}
+	reports, err := j.copier.Preflight(files)
+	if err != nil {
+		j.log.Warn("사전 점검 실패", "error", err)
+		return j.preflight != copier.PreflightRefuse
+	}
+	ok := true
+	for _, r := range reports {
+		if !r.Sufficient() {
+			j.recordError(fmt.Errorf("사전 점검: [%s] 대상 공간/inode 부족 (필요 %d B / 여유 %d B, inode 필요 %d / 여유 %d)",
+				r.Target, r.NeededBytes, r.FreeBytes, r.NeededInodes, r.FreeInodes))
+			ok = false
+		}
+	}
+	return ok || j.preflight != copier.PreflightRefuse
+}
+
+// finish records the final state and closes subscriber channels
+func (j *Job) finish(failed int) {
+	j.mu.Lock()
+	switch {
+	case j.canceled:
+		j.state = StateCanceled
+	case failed > 0:
+		j.state = StateFailed
+	default:
+		j.state = StateDone
+	}
+	j.finished = time.Now()
+	j.log.Info("작업 종료", "state", j.state, "failed", failed, "elapsed", j.finished.Sub(j.created).Round(time.Millisecond))
+	subs := j.subs
+	j.subs = make(map[chan Event]struct{})
+	j.mu.Unlock()
+
+	final := Event{Type: "state", Data: j.Status()}
+	for ch := range subs {
+		select {
+		case ch <- final:
+		default:
+		}
+		close(ch)
+	}
+	close(j.doneCh)
+}
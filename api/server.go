@@ -0,0 +1,177 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const sseKeepAlive = 15 * time.Second
+
+// Server exposes a Manager over HTTP:
+//
+//	POST /jobs                 작업 제출 (JobRequest)
+//	GET  /jobs                 작업 목록
+//	GET  /jobs/{id}            작업 상태
+//	POST /jobs/{id}/cancel     취소
+//	POST /jobs/{id}/pause      일시정지
+//	POST /jobs/{id}/resume     재개
+//	GET  /jobs/{id}/events     진행 상황 (Server-Sent Events)
+type Server struct {
+	Manager *Manager
+	Token   string // 비어 있지 않으면 Authorization: Bearer <token> 필요
+}
+
+// Handler returns the HTTP handler for the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", s.submit)
+	mux.HandleFunc("GET /jobs", s.list)
+	mux.HandleFunc("GET /jobs/{id}", s.withJob(func(w http.ResponseWriter, r *http.Request, j *Job) {
+		writeJSON(w, http.StatusOK, j.Status())
+	}))
+	mux.HandleFunc("POST /jobs/{id}/cancel", s.withJob(func(w http.ResponseWriter, r *http.Request, j *Job) {
+		j.Cancel()
+		writeJSON(w, http.StatusAccepted, j.Status())
+	}))
+	mux.HandleFunc("POST /jobs/{id}/pause", s.withJob(func(w http.ResponseWriter, r *http.Request, j *Job) {
+		s.control(w, j, j.Pause)
+	}))
+	mux.HandleFunc("POST /jobs/{id}/resume", s.withJob(func(w http.ResponseWriter, r *http.Request, j *Job) {
+		s.control(w, j, j.Resume)
+	}))
+	mux.HandleFunc("GET /jobs/{id}/events", s.withJob(s.events))
+	return s.auth(mux)
+}
+
+// auth checks the bearer token when one is configured
+func (s *Server) auth(next http.Handler) http.Handler {
+	if s.Token == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("인증 토큰 불일치"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) withJob(h func(http.ResponseWriter, *http.Request, *Job)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		j, err := s.Manager.Get(r.PathValue("id"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		h(w, r, j)
+	}
+}
+
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	var req JobRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("잘못된 요청 본문: %v", err))
+		return
+	}
+	j, err := s.Manager.Submit(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Location", "/jobs/"+j.ID())
+	writeJSON(w, http.StatusCreated, j.Status())
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	jobs := s.Manager.List()
+	out := make([]JobStatus, 0, len(jobs))
+	for _, j := range jobs {
+		out = append(out, j.Status())
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) control(w http.ResponseWriter, j *Job, action func() error) {
+	if err := action(); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, j.Status())
+}
+
+// events streams job events as SSE until the job ends or the client leaves.
+// The first event is the current status so late subscribers start complete.
+func (s *Server) events(w http.ResponseWriter, r *http.Request, j *Job) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("스트리밍을 지원하지 않는 연결"))
+		return
+	}
+	ch, unsubscribe := j.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if writeEvent(w, Event{Type: "state", Data: j.Status()}) != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			// 프록시가 유휴 연결을 끊지 않도록 주석 줄 전송
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case ev, open := <-ch:
+			if !open {
+				// 작업 종료: 최종 상태를 보내고 스트림을 닫음
+				_ = writeEvent(w, Event{Type: "end", Data: j.Status()})
+				flusher.Flush()
+				return
+			}
+			if writeEvent(w, ev) != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, ev Event) error {
+	b, err := json.Marshal(ev.Data)
+	if err != nil {
+		return err
+	}
+	// data 에 줄바꿈이 없도록 JSON 한 줄로 보냄
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, strings.TrimSpace(string(b)))
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"superfast-copy-util/copier"
+)
+
+// testServer runs the API with a builder that copies with default settings;
+// the option "paused" starts the copier paused so tests can drive it
+func testServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	m := NewManager(func(req JobRequest) (JobSetup, error) {
+		c := copier.NewCopier(req.Source, req.Target, false)
+		if req.Options["paused"] == "true" {
+			c.Pause()
+		}
+		return JobSetup{Copier: c, Preflight: copier.PreflightOff}, nil
+	})
+	srv := httptest.NewServer((&Server{Manager: m, Token: token}).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// call sends a request and decodes the JSON answer into out (when not nil)
+func call(t *testing.T, srv *httptest.Server, method, path, token, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// sourceTree creates a small source directory and returns source and target paths
+func sourceTree(t *testing.T) (string, string) {
+	t.Helper()
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	for _, rel := range []string{"a.txt", "sub/b.txt"} {
+		p := filepath.Join(src, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return src, filepath.Join(base, "dst")
+}
+
+// waitState polls the job until it reaches state
+func waitState(t *testing.T, srv *httptest.Server, id string, state JobState) JobStatus {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var st JobStatus
+		call(t, srv, "GET", "/jobs/"+id, "", "", &st)
+		if st.State == state {
+			return st
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s is %s, want %s", id, st.State, state)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestJobLifecycle(t *testing.T) {
+	srv := testServer(t, "")
+	src, dst := sourceTree(t)
+
+	var st JobStatus
+	body := fmt.Sprintf(`{"source": %q, "target": %q, "options": {"paused": "true"}}`, src, dst)
+	if code := call(t, srv, "POST", "/jobs", "", body, &st); code != http.StatusCreated {
+		t.Fatalf("submit = %d", code)
+	}
+	waitState(t, srv, st.ID, StateCopying)
+	if code := call(t, srv, "POST", "/jobs/"+st.ID+"/pause", "", "", &st); code != http.StatusOK || st.State != StatePaused {
+		t.Fatalf("pause = %d, state %s", code, st.State)
+	}
+	if code := call(t, srv, "POST", "/jobs/"+st.ID+"/resume", "", "", &st); code != http.StatusOK {
+		t.Fatalf("resume = %d", code)
+	}
+	st = waitState(t, srv, st.ID, StateDone)
+	if st.Finished == nil || st.Copy.CompletedFiles != 2 || st.ErrorCount != 0 {
+		t.Errorf("final status %+v", st)
+	}
+	if b, _ := os.ReadFile(filepath.Join(dst, "sub", "b.txt")); string(b) != "sub/b.txt" {
+		t.Error("files not copied")
+	}
+	// 끝난 작업은 일시정지할 수 없음
+	if code := call(t, srv, "POST", "/jobs/"+st.ID+"/pause", "", "", nil); code != http.StatusConflict {
+		t.Errorf("pause after done = %d, want 409", code)
+	}
+	var list []JobStatus
+	if call(t, srv, "GET", "/jobs", "", "", &list); len(list) != 1 || list[0].ID != st.ID {
+		t.Errorf("list = %+v", list)
+	}
+}
+
+func TestJobCancel(t *testing.T) {
+	srv := testServer(t, "")
+	src, dst := sourceTree(t)
+	var st JobStatus
+	call(t, srv, "POST", "/jobs", "", fmt.Sprintf(`{"source": %q, "target": %q, "options": {"paused": "true"}}`, src, dst), &st)
+	waitState(t, srv, st.ID, StateCopying)
+	if code := call(t, srv, "POST", "/jobs/"+st.ID+"/cancel", "", "", nil); code != http.StatusAccepted {
+		t.Fatalf("cancel = %d", code)
+	}
+	waitState(t, srv, st.ID, StateCanceled)
+}
+
+func TestEventsStream(t *testing.T) {
+	srv := testServer(t, "")
+	src, dst := sourceTree(t)
+	var st JobStatus
+	call(t, srv, "POST", "/jobs", "", fmt.Sprintf(`{"source": %q, "target": %q, "options": {"paused": "true"}}`, src, dst), &st)
+
+	resp, err := http.Get(srv.URL + "/jobs/" + st.ID + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	call(t, srv, "POST", "/jobs/"+st.ID+"/resume", "", "", nil)
+
+	// 첫 이벤트는 현재 상태, 마지막은 end 와 최종 상태
+	var types []string
+	var last JobStatus
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		line := sc.Text()
+		if typ, ok := strings.CutPrefix(line, "event: "); ok {
+			types = append(types, typ)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok && types[len(types)-1] == "end" {
+			if err := json.Unmarshal([]byte(data), &last); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if len(types) < 2 || types[0] != "state" || types[len(types)-1] != "end" {
+		t.Fatalf("event types %v", types)
+	}
+	if last.State != StateDone {
+		t.Errorf("end event state = %s", last.State)
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	srv := testServer(t, "secret")
+	tests := []struct {
+		name, method, path, token, body string
+		want                            int
+	}{
+		{"missing token", "GET", "/jobs", "", "", http.StatusUnauthorized},
+		{"wrong token", "GET", "/jobs", "guess", "", http.StatusUnauthorized},
+		{"valid token", "GET", "/jobs", "secret", "", http.StatusOK},
+		{"unknown job", "GET", "/jobs/42", "secret", "", http.StatusNotFound},
+		{"unknown field", "POST", "/jobs", "secret", `{"source": "a", "target": "b", "verbose": true}`, http.StatusBadRequest},
+		{"missing target", "POST", "/jobs", "secret", `{"source": "a"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out any
+			if code := call(t, srv, tt.method, tt.path, tt.token, tt.body, &out); code != tt.want {
+				t.Errorf("status = %d, want %d (%v)", code, tt.want, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// profileFile is the layout of a config file. Keys are the CLI flag names
+// without the dash, plus source/target/targets for the folders; "defaults"
+// applies to every profile:
+//
+//	{
+//	  "defaults": {"workers": 8, "durability": "file"},
+//	  "profiles": {
+//	    "nightly-assets": {
+//	      "source": "/data/assets", "target": "/backup/assets",
+//	      "exclude": ["*.tmp", "cache/"], "conflict": "update", "verify": true
+//	    }
+//	  }
+//	}
+type profileFile struct {
+	Defaults map[string]json.RawMessage            `json:"defaults"`
+	Profiles map[string]map[string]json.RawMessage `json:"profiles"`
+}
+
+// jobProfile is a profile merged with the file's defaults
+type jobProfile struct {
+	name    string
+	source  string
+	target  string
+	targets []string            // 추가 대상
+	values  map[string][]string // 플래그 이름 → 값 (목록 키는 여러 개)
+	keys    map[string]string   // 플래그 이름 → 오류 메시지용 키 위치
+}
+
+// envPrefix names the environment variable for a flag: -buffer-mb → SUPERFAST_BUFFER_MB
+const envPrefix = "SUPERFAST_"
+
+// layerExempt are flags that select the mode or the config itself and so
+// cannot come from a profile or the environment
+var layerExempt = map[string]bool{"cli": true, "ui": true, "config": true, "profile": true}
+
+// defaultConfigPath is used by -profile when -config is not given
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "superfast-copy.json"
+	}
+	return filepath.Join(dir, "superfast-copy-util", "config.json")
+}
+
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// optionSources remembers where option values that did not come from the
+// command line were taken from, so validation errors can name the key
+type optionSources map[string]string
+
+// explain rewrites an option validation error to point at its origin
+func (s optionSources) explain(err error) error {
+	var oe *optionError
+	if errors.As(err, &oe) {
+		if where, ok := s[oe.name]; ok {
+			return fmt.Errorf("%s: %v", where, oe.err)
+		}
+	}
+	return err
+}
+
+// loadProfile reads a config file and resolves one profile; an empty name
+// yields just the defaults
+func loadProfile(path, name string) (*jobProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 읽기 실패: %v", err)
+	}
+	var file profileFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			line, col := lineCol(data, syn.Offset)
+			return nil, fmt.Errorf("설정 파일 %s:%d:%d: JSON 문법 오류: %v", path, line, col, err)
+		}
+		return nil, fmt.Errorf("설정 파일 %s: %v", path, err)
+	}
+
+	prof := &jobProfile{name: name, values: make(map[string][]string), keys: make(map[string]string)}
+	if err := prof.merge(path, "defaults", file.Defaults); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		entries, ok := file.Profiles[name]
+		if !ok {
+			names := make([]string, 0, len(file.Profiles))
+			for n := range file.Profiles {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("설정 파일 %s: 프로필 %q 없음 (있는 프로필: %s)", path, name, strings.Join(names, ", "))
+		}
+		if err := prof.merge(path, "profiles."+name, entries); err != nil {
+			return nil, err
+		}
+	}
+	return prof, nil
+}
+
+// merge adds a section's keys, overriding earlier sections
+func (p *jobProfile) merge(path, section string, entries map[string]json.RawMessage) error {
+	for key, raw := range entries {
+		where := fmt.Sprintf("설정 파일 %s: %s.%s", path, section, key)
+		values, list, err := decodeValue(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %v", where, err)
+		}
+		switch key {
+		case "source", "target":
+			if list || len(values) != 1 {
+				return fmt.Errorf("%s: 문자열 경로여야 합니다", where)
+			}
+			if key == "source" {
+				p.source = values[0]
+			} else {
+				p.target = values[0]
+			}
+		case "targets":
+			p.targets = values
+		default:
+			p.values[key] = values
+			p.keys[key] = where
+		}
+	}
+	return nil
+}
+
+// decodeValue turns a JSON scalar or array of scalars into flag strings
+func decodeValue(raw json.RawMessage) (values []string, list bool, err error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false, err
+	}
+	if items, ok := v.([]any); ok {
+		for _, item := range items {
+			s, err := scalarString(item)
+			if err != nil {
+				return nil, true, err
+			}
+			values = append(values, s)
+		}
+		return values, true, nil
+	}
+	s, err := scalarString(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return []string{s}, false, nil
+}
+
+func scalarString(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case json.Number:
+		return x.String(), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	}
+	return "", fmt.Errorf("문자열, 숫자, 불리언 또는 그 배열이어야 합니다")
+}
+
+// applyLayers fills every flag not given on the command line from, in order
+// of precedence, its SUPERFAST_* environment variable and then the profile
+// (flags > env > file > built-in defaults)
+func applyLayers(fs *flag.FlagSet, prof *jobProfile) (optionSources, error) {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	sources := make(optionSources)
+
+	if prof != nil {
+		keys := make([]string, 0, len(prof.values))
+		for key := range prof.values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if fs.Lookup(key) == nil || layerExempt[key] {
+				return nil, fmt.Errorf("%s: 알 수 없는 키", prof.keys[key])
+			}
+		}
+	}
+
+	var firstErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if firstErr != nil || explicit[f.Name] || layerExempt[f.Name] {
+			return
+		}
+		if env, ok := os.LookupEnv(envName(f.Name)); ok {
+			where := "환경 변수 " + envName(f.Name)
+			if err := f.Value.Set(env); err != nil {
+				firstErr = fmt.Errorf("%s: 잘못된 값 %q: %v", where, env, err)
+			}
+			sources[f.Name] = where
+			return
+		}
+		if prof == nil {
+			return
+		}
+		values, ok := prof.values[f.Name]
+		if !ok {
+			return
+		}
+		where := prof.keys[f.Name]
+		if _, isList := f.Value.(*stringList); !isList && len(values) != 1 {
+			firstErr = fmt.Errorf("%s: 목록 값은 include/exclude 에만 쓸 수 있습니다", where)
+			return
+		}
+		for _, v := range values {
+			if err := f.Value.Set(v); err != nil {
+				firstErr = fmt.Errorf("%s: 잘못된 값 %q: %v", where, v, err)
+				return
+			}
+		}
+		sources[f.Name] = where
+	})
+	return sources, firstErr
+}
+
+// lineCol converts a byte offset into a 1-based line and column
+func lineCol(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"superfast-copy-util/copier"
+)
+
+const testConfig = `{
+  "defaults": {"workers": 8, "conflict": "skip", "verify": true},
+  "profiles": {
+    "nightly": {
+      "source": "/data/assets", "target": "/backup/assets", "targets": ["/mirror/assets"],
+      "exclude": ["*.tmp", "cache/"], "conflict": "update", "buffer-mb": 16
+    }
+  }
+}`
+
+// writeConfig stores a config file in a temp dir and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// parseLayered parses args like the CLI does: flags, then environment and
+// profile layers, then option validation
+func parseLayered(t *testing.T, args []string, prof *jobProfile) (copyOptions, optionSources, error) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var opts copyOptions
+	finish := registerCopyFlags(fs, &opts)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse %v: %v", args, err)
+	}
+	sources, err := applyLayers(fs, prof)
+	if err != nil {
+		return opts, sources, err
+	}
+	return opts, sources, sources.explain(finish())
+}
+
+func TestOptionPrecedence(t *testing.T) {
+	prof, err := loadProfile(writeConfig(t, testConfig), "nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prof.source != "/data/assets" || prof.target != "/backup/assets" || !reflect.DeepEqual(prof.targets, []string{"/mirror/assets"}) {
+		t.Fatalf("folders = %q %q %q", prof.source, prof.target, prof.targets)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		profile  bool
+		workers  int
+		bufferMB int
+		conflict copier.ConflictPolicy
+		verify   bool
+		exclude  []string
+		source   string // workers 값의 출처 ("" 이면 명령행 또는 기본값)
+	}{
+		{name: "built-in defaults", workers: 0, bufferMB: 4, conflict: copier.ConflictOverwrite},
+		{name: "profile over defaults section", profile: true, workers: 8, bufferMB: 16, conflict: copier.ConflictUpdate,
+			verify: true, exclude: []string{"*.tmp", "cache/"}, source: "defaults.workers"},
+		{name: "env over profile", profile: true, env: map[string]string{"SUPERFAST_WORKERS": "4", "SUPERFAST_VERIFY": "false"},
+			workers: 4, bufferMB: 16, conflict: copier.ConflictUpdate, exclude: []string{"*.tmp", "cache/"}, source: "환경 변수 SUPERFAST_WORKERS"},
+		{name: "flag over env and profile", profile: true, args: []string{"-workers", "2", "-conflict", "overwrite"},
+			env: map[string]string{"SUPERFAST_WORKERS": "4"}, workers: 2, bufferMB: 16, conflict: copier.ConflictOverwrite,
+			verify: true, exclude: []string{"*.tmp", "cache/"}},
+		{name: "flag list replaces profile list", profile: true, args: []string{"-exclude", "x"},
+			workers: 8, bufferMB: 16, conflict: copier.ConflictUpdate, verify: true, exclude: []string{"x"}, source: "defaults.workers"},
+		{name: "env list is comma separated", profile: true, env: map[string]string{"SUPERFAST_EXCLUDE": "a, b"},
+			workers: 8, bufferMB: 16, conflict: copier.ConflictUpdate, verify: true, exclude: []string{"a", "b"}, source: "defaults.workers"},
+		{name: "env without profile", env: map[string]string{"SUPERFAST_BUFFER_MB": "32"}, bufferMB: 32, conflict: copier.ConflictOverwrite},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var p *jobProfile
+			if tt.profile {
+				p = prof
+			}
+			opts, sources, err := parseLayered(t, tt.args, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if opts.workers != tt.workers || opts.bufferMB != tt.bufferMB || opts.conflict != tt.conflict || opts.verify != tt.verify {
+				t.Errorf("workers=%d buffer-mb=%d conflict=%v verify=%v, want %d %d %v %v",
+					opts.workers, opts.bufferMB, opts.conflict, opts.verify, tt.workers, tt.bufferMB, tt.conflict, tt.verify)
+			}
+			if !reflect.DeepEqual([]string(opts.filter.Exclude), tt.exclude) {
+				t.Errorf("exclude = %q, want %q", opts.filter.Exclude, tt.exclude)
+			}
+			switch got := sources["workers"]; {
+			case tt.source == "" && got != "":
+				t.Errorf("workers source = %q, want none", got)
+			case tt.source != "" && !strings.Contains(got, tt.source):
+				t.Errorf("workers source = %q, want %q", got, tt.source)
+			}
+		})
+	}
+}
+
+func TestOptionLayerErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		profile string
+		env     map[string]string
+		want    []string // 오류 메시지에 들어 있어야 하는 조각
+	}{
+		{name: "unknown key", config: `{"profiles": {"p": {"wrokers": 4}}}`, profile: "p",
+			want: []string{"profiles.p.wrokers", "알 수 없는 키"}},
+		{name: "mode flag in profile", config: `{"defaults": {"cli": true}}`,
+			want: []string{"defaults.cli", "알 수 없는 키"}},
+		{name: "list for scalar flag", config: `{"defaults": {"workers": [1, 2]}}`,
+			want: []string{"defaults.workers", "목록 값은"}},
+		{name: "bad profile value", config: `{"defaults": {"workers": "many"}}`,
+			want: []string{"defaults.workers", "잘못된 값"}},
+		{name: "bad env value", config: `{}`, env: map[string]string{"SUPERFAST_WORKERS": "many"},
+			want: []string{"환경 변수 SUPERFAST_WORKERS", "잘못된 값"}},
+		{name: "validation names the profile key", config: `{"profiles": {"p": {"conflict": "bogus"}}}`, profile: "p",
+			want: []string{"profiles.p.conflict", "bogus"}},
+		{name: "validation names the env var", config: `{}`, env: map[string]string{"SUPERFAST_BUFFER_MB": "0"},
+			want: []string{"환경 변수 SUPERFAST_BUFFER_MB", "1 이상"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			prof, err := loadProfile(writeConfig(t, tt.config), tt.profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _, err = parseLayered(t, nil, prof)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("error %q does not mention %q", err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		profile string
+		want    string
+	}{
+		{"missing profile", testConfig, "daily", `프로필 "daily" 없음 (있는 프로필: nightly)`},
+		{"syntax error position", "{\n  \"defaults\": {\"workers\": 8,}\n}", "", ":2:"},
+		{"unknown section", `{"default": {}}`, "", "unknown field"},
+		{"source must be a string", `{"profiles": {"p": {"source": ["a", "b"]}}}`, "p", "문자열 경로여야"},
+		{"object value", `{"defaults": {"workers": {"n": 1}}}`, "", "문자열, 숫자, 불리언"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadProfile(writeConfig(t, tt.config), tt.profile)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package copier
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	tuneInterval   = 2 * time.Second
+	tuneThreshold  = 0.05 // 5% 이상 변해야 개선/악화로 판단
+	minTuneBuffer  = 256 * 1024
+	maxTuneBuffer  = 16 * 1024 * 1024
+	defaultBufSize = 1 * 1024 * 1024
+)
+
+// TuneEvent records a single autotune decision (for diagnostics)
+type TuneEvent struct {
+	Time        time.Time
+	Workers     int
+	BufferSize  int
+	BytesPerSec float64
+	FilesPerSec float64
+	Reason      string
+}
+
+// String formats the event as a single log line
+func (e TuneEvent) String() string {
+	return fmt.Sprintf("%s workers=%d buffer=%dKB %.1fMB/s %.1f files/s: %s",
+		e.Time.Format("15:04:05"), e.Workers, e.BufferSize/1024,
+		e.BytesPerSec/(1024*1024), e.FilesPerSec, e.Reason)
+}
+
+// SetAutoTune enables the adaptive controller which grows or shrinks the active
+// worker count within [minWorkers, maxWorkers] and adjusts the buffer size to
+// find the throughput plateau (call before CopyFilesParallel)
+func (c *Copier) SetAutoTune(minWorkers, maxWorkers int) {
+	if minWorkers < 1 {
+		minWorkers = 1
+	}
+	if maxWorkers < minWorkers {
+		maxWorkers = minWorkers
+	}
+	c.autoTune = true
+	c.minWorkers = minWorkers
+	c.maxWorkers = maxWorkers
+}
+
+// TuneHistory returns the autotune decisions made so far
+func (c *Copier) TuneHistory() []TuneEvent {
+	c.tuneMu.Lock()
+	defer c.tuneMu.Unlock()
+	out := make([]TuneEvent, len(c.tuneLog))
+	copy(out, c.tuneLog)
+	return out
+}
+
+// spawnCount returns how many worker goroutines to start
+func (c *Copier) spawnCount() int {
+	if c.autoTune {
+		return c.maxWorkers
+	}
+	return c.workerCount
+}
+
+// initWorkerGate sets the initial active worker count and buffer size
+func (c *Copier) initWorkerGate() {
+	active := c.workerCount
+	if c.autoTune {
+		if active < c.minWorkers {
+			active = c.minWorkers
+		}
+		if active > c.maxWorkers {
+			active = c.maxWorkers
+		}
+	}
+	bufSize := c.bufferSize
+	if bufSize <= 0 {
+		bufSize = defaultBufSize
+	}
+	atomic.StoreInt32(&c.activeWorkers, int32(active))
+	atomic.StoreInt64(&c.activeBuffer, int64(bufSize))
+	atomic.StoreInt32(&c.drained, 0)
+}
+
+// currentBufferSize returns the buffer size workers should use for the next file
+func (c *Copier) currentBufferSize() int {
+	if n := atomic.LoadInt64(&c.activeBuffer); n > 0 {
+		return int(n)
+	}
+	if c.bufferSize > 0 {
+		return c.bufferSize
+	}
+	return defaultBufSize
+}
+
+// releaseParked lets workers above the active count exit once the work
+// channels are closed and empty; until then they stay parked so the
+// active count remains the concurrency cap
+func (c *Copier) releaseParked() {
+	atomic.StoreInt32(&c.drained, 1)
+	c.pauseMu.Lock()
+	c.pauseCond.Broadcast()
+	c.pauseMu.Unlock()
+}
+
+// waitTurn blocks while paused or while worker id is above the active count;
+// returns false when the copy was canceled or stopped
+func (c *Copier) waitTurn(id int) bool {
+	parked := func() bool {
+		if atomic.LoadInt32(&c.canceled) == 1 || atomic.LoadInt32(&c.stopping) == 1 {
+			return false
+		}
+		if atomic.LoadInt32(&c.paused) == 1 {
+			return true
+		}
+		return int32(id) >= atomic.LoadInt32(&c.activeWorkers) && atomic.LoadInt32(&c.drained) == 0
+	}
+	if parked() {
+		c.pauseMu.Lock()
+		for parked() {
+			c.pauseCond.Wait()
+		}
+		c.pauseMu.Unlock()
+	}
+	return atomic.LoadInt32(&c.canceled) == 0 && atomic.LoadInt32(&c.stopping) == 0
+}
+
+// setActiveWorkers changes the active worker count and wakes parked workers
+func (c *Copier) setActiveWorkers(n int) {
+	atomic.StoreInt32(&c.activeWorkers, int32(n))
+	c.pauseMu.Lock()
+	c.pauseCond.Broadcast()
+	c.pauseMu.Unlock()
+}
+
+// recordTune appends a decision to the tune log
+func (c *Copier) recordTune(ev TuneEvent) {
+	c.tuneMu.Lock()
+	c.tuneLog = append(c.tuneLog, ev)
+	c.tuneMu.Unlock()
+	c.log.Info("자동 조정", "workers", ev.Workers, "bufferKB", ev.BufferSize/1024,
+		"MBps", ev.BytesPerSec/(1024*1024), "filesPerSec", ev.FilesPerSec, "reason", ev.Reason)
+}
+
+// autoTuneLoop measures throughput periodically and hill-climbs the worker count:
+// keep moving in the same direction while throughput improves, reverse when it
+// drops, and hold once it stops changing (plateau). Buffer size follows the
+// observed average file size.
+func (c *Copier) autoTuneLoop(done <-chan bool) {
+	ticker := time.NewTicker(tuneInterval)
+	defer ticker.Stop()
+
+	var prevBytes, prevFiles int64
+	prevAt := time.Now()
+	var lastScore float64
+	direction := 1
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		if c.IsPaused() {
+			// 일시정지 구간은 측정에서 제외
+			prevBytes = atomic.LoadInt64(&c.bytesCopied)
+			c.progressMux.Lock()
+			prevFiles = c.progress.CompletedFiles
+			c.progressMux.Unlock()
+			prevAt = time.Now()
+			continue
+		}
+
+		now := time.Now()
+		bytes := atomic.LoadInt64(&c.bytesCopied)
+		c.progressMux.Lock()
+		files := c.progress.CompletedFiles
+		c.progressMux.Unlock()
+		dt := now.Sub(prevAt).Seconds()
+		if dt <= 0 {
+			continue
+		}
+		bps := float64(bytes-prevBytes) / dt
+		fps := float64(files-prevFiles) / dt
+		deltaFiles := files - prevFiles
+		deltaBytes := bytes - prevBytes
+		prevBytes, prevFiles, prevAt = bytes, files, now
+
+		// 작은 파일 위주면 바이트 처리량이 거의 0일 수 있어 파일 처리량으로 보정
+		score := bps
+		if score == 0 {
+			score = fps
+		}
+
+		active := int(atomic.LoadInt32(&c.activeWorkers))
+		var next int
+		var reason string
+		next, direction, reason = c.nextWorkers(active, direction, lastScore, score)
+		lastScore = score
+		if next != active {
+			c.setActiveWorkers(next)
+		}
+
+		buf := c.currentBufferSize()
+		nextBuf := nextBufferSize(buf, deltaBytes, deltaFiles)
+		if nextBuf != buf {
+			atomic.StoreInt64(&c.activeBuffer, int64(nextBuf))
+			reason += fmt.Sprintf(", 버퍼 %dKB→%dKB", buf/1024, nextBuf/1024)
+		}
+
+		c.recordTune(TuneEvent{
+			Time:        now,
+			Workers:     next,
+			BufferSize:  nextBuf,
+			BytesPerSec: bps,
+			FilesPerSec: fps,
+			Reason:      reason,
+		})
+	}
+}
+
+// nextWorkers is one hill-climbing step: keep moving in the same direction
+// while the score improves, reverse when it drops, hold on a plateau. The
+// step is a quarter of the active count, clamped to [minWorkers, maxWorkers]
+func (c *Copier) nextWorkers(active, direction int, lastScore, score float64) (int, int, string) {
+	var reason string
+	switch {
+	case lastScore == 0:
+		reason = "초기 측정, 워커 증가 시도"
+	case score > lastScore*(1+tuneThreshold):
+		reason = "처리량 개선, 같은 방향 유지"
+	case score < lastScore*(1-tuneThreshold):
+		direction = -direction
+		reason = "처리량 감소, 방향 반전"
+	default:
+		return active, direction, "처리량 정체(plateau), 유지"
+	}
+	step := active / 4
+	if step < 1 {
+		step = 1
+	}
+	next := active + direction*step
+	if next < c.minWorkers {
+		next = c.minWorkers
+		direction = 1
+	}
+	if next > c.maxWorkers {
+		next = c.maxWorkers
+		direction = -1
+	}
+	return next, direction, reason
+}
+
+// nextBufferSize follows the average file size of the last interval: the
+// buffer doubles for files much larger than it and halves for much smaller ones
+func nextBufferSize(buf int, deltaBytes, deltaFiles int64) int {
+	if deltaFiles <= 0 {
+		return buf
+	}
+	avg := deltaBytes / deltaFiles
+	if avg > int64(buf)*4 && buf < maxTuneBuffer {
+		return buf * 2
+	}
+	if avg < int64(buf)/4 && buf > minTuneBuffer {
+		return buf / 2
+	}
+	return buf
+}
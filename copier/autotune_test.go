@@ -0,0 +1,81 @@
+package copier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextWorkers(t *testing.T) {
+	c := NewCopier("", "", false)
+	c.SetAutoTune(2, 16)
+	tests := []struct {
+		name              string
+		active, dir       int
+		last, score       float64
+		wantNext, wantDir int
+	}{
+		{"first sample grows", 8, 1, 0, 100, 10, 1},
+		{"improvement keeps direction", 8, 1, 100, 120, 10, 1},
+		{"improvement keeps shrinking", 8, -1, 100, 120, 6, -1},
+		{"drop reverses", 8, 1, 100, 80, 6, -1},
+		{"plateau holds", 8, 1, 100, 103, 8, 1},
+		{"step is at least one", 3, 1, 100, 200, 4, 1},
+		{"clamped at max", 15, 1, 100, 200, 16, -1},
+		{"clamped at min", 2, -1, 100, 200, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, dir, reason := c.nextWorkers(tt.active, tt.dir, tt.last, tt.score)
+			if next != tt.wantNext || dir != tt.wantDir {
+				t.Errorf("nextWorkers = %d, %d (%s), want %d, %d", next, dir, reason, tt.wantNext, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestNextBufferSize(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		name  string
+		buf   int
+		bytes int64
+		files int64
+		want  int
+	}{
+		{"no files finished", mb, 0, 0, mb},
+		{"large files double", mb, 10 * 8 * mb, 10, 2 * mb},
+		{"small files halve", mb, 100 * 1024, 10, mb / 2},
+		{"similar size holds", mb, 10 * 2 * mb, 10, mb},
+		{"capped at max", maxTuneBuffer, 64 * maxTuneBuffer, 1, maxTuneBuffer},
+		{"floored at min", minTuneBuffer, 1, 1, minTuneBuffer},
+	}
+	for _, tt := range tests {
+		if got := nextBufferSize(tt.buf, tt.bytes, tt.files); got != tt.want {
+			t.Errorf("%s: nextBufferSize = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Workers above the active count stay parked until the count grows
+func TestWaitTurnParksInactiveWorkers(t *testing.T) {
+	c := NewCopier("", "", false)
+	c.SetWorkerCount(1)
+	c.SetAutoTune(1, 4)
+	c.initWorkerGate()
+
+	if !c.waitTurn(0) {
+		t.Fatal("worker 0 should run")
+	}
+	woke := make(chan bool, 1)
+	go func() { woke <- c.waitTurn(2) }()
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-woke:
+		t.Fatal("worker 2 ran while only one worker is active")
+	default:
+	}
+	c.setActiveWorkers(3)
+	if !<-woke {
+		t.Error("worker 2 did not run after the active count grew")
+	}
+}
@@ -0,0 +1,141 @@
+package copier
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BackupMode selects how an existing target file is kept before overwriting
+type BackupMode int
+
+const (
+	BackupNone      BackupMode = iota // 백업 없이 덮어쓰기 (기본)
+	BackupSuffix                      // name~ (접미사 지정 가능)
+	BackupNumbered                    // name.~1~, name.~2~ ...
+	BackupTimestamp                   // name.20060102-150405 (작업 시작 시각)
+	BackupDir                         // 백업 디렉터리에 같은 트리 구조로 이동
+)
+
+// BackupOptions configures backups of overwritten files for a job
+type BackupOptions struct {
+	Mode   BackupMode
+	Suffix string // BackupSuffix 접미사 (기본 "~")
+	Dir    string // BackupDir 경로; 상대 경로면 각 대상 루트 기준
+}
+
+// ParseBackupMode converts a flag value into a BackupMode
+func ParseBackupMode(name string) (BackupMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return BackupNone, nil
+	case "suffix":
+		return BackupSuffix, nil
+	case "numbered":
+		return BackupNumbered, nil
+	case "timestamp":
+		return BackupTimestamp, nil
+	case "dir":
+		return BackupDir, nil
+	}
+	return BackupNone, fmt.Errorf("알 수 없는 백업 방식: %s (none, suffix, numbered, timestamp, dir)", name)
+}
+
+// SetBackup moves existing target files aside before they are overwritten
+// (call before CopyFilesParallel)
+func (c *Copier) SetBackup(opts BackupOptions) {
+	if opts.Mode == BackupSuffix && opts.Suffix == "" {
+		opts.Suffix = "~"
+	}
+	c.backup = opts
+}
+
+// backupExisting moves an existing regular target file aside and returns the
+// new location ("" when there was nothing to back up)
+func (c *Copier) backupExisting(t TargetResult, relPath string) (string, error) {
+	if c.backup.Mode == BackupNone {
+		return "", nil
+	}
+	longDst := normalizeLongPath(t.Path)
+	info, err := os.Lstat(longDst)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", nil
+	}
+	backupPath, err := c.backupPathFor(t, relPath)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(normalizeLongPath(backupPath)), 0755); err != nil {
+		return "", fmt.Errorf("백업 디렉터리 생성 실패: %w", err)
+	}
+	if err := moveAside(longDst, normalizeLongPath(backupPath)); err != nil {
+		return "", fmt.Errorf("기존 파일 백업 실패: %w", err)
+	}
+	return backupPath, nil
+}
+
+// backupPathFor computes where an existing target file is moved to
+func (c *Copier) backupPathFor(t TargetResult, relPath string) (string, error) {
+	switch c.backup.Mode {
+	case BackupSuffix:
+		return t.Path + c.backup.Suffix, nil
+	case BackupTimestamp:
+		return t.Path + "." + c.startTime.Format("20060102-150405"), nil
+	case BackupNumbered:
+		for n := 1; n < 100000; n++ {
+			p := fmt.Sprintf("%s.~%d~", t.Path, n)
+			if _, err := os.Lstat(normalizeLongPath(p)); os.IsNotExist(err) {
+				return p, nil
+			}
+		}
+		return "", fmt.Errorf("사용 가능한 백업 번호 없음: %s", t.Path)
+	case BackupDir:
+		dir := c.backup.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(t.Root, dir)
+		} else if len(c.extraTargets) > 0 {
+			// 여러 대상이 같은 백업 디렉터리를 쓰면 대상 이름으로 구분
+			dir = filepath.Join(dir, filepath.Base(filepath.Clean(t.Root)))
+		}
+		rel, _ := c.targetRel(t.Root, relPath)
+		return filepath.Join(dir, rel), nil
+	}
+	return "", nil
+}
+
+// restoreBackup puts a backed-up file back after the new copy failed
+func restoreBackup(t TargetResult) {
+	if t.BackupPath == "" {
+		return
+	}
+	_ = moveAside(normalizeLongPath(t.BackupPath), normalizeLongPath(t.Path))
+}
+
+// moveAside renames src to dst, copying across filesystems when needed
+func moveAside(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
@@ -0,0 +1,88 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    BackupOptions
+		backups []string // 두 번 덮어쓴 뒤 남아 있어야 하는 백업 (내용은 덮어쓰기 직전 판)
+		olds    []string
+	}{
+		{"suffix", BackupOptions{Mode: BackupSuffix}, []string{"f.txt~"}, []string{"v2"}},
+		{"custom suffix", BackupOptions{Mode: BackupSuffix, Suffix: ".bak"}, []string{"f.txt.bak"}, []string{"v2"}},
+		{"numbered", BackupOptions{Mode: BackupNumbered}, []string{"f.txt.~1~", "f.txt.~2~"}, []string{"v1", "v2"}},
+		{"dir", BackupOptions{Mode: BackupDir, Dir: ".old"}, []string{".old/f.txt"}, []string{"v2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "src")
+			dst := filepath.Join(base, "dst")
+			writeTree(t, dst, map[string]string{"f.txt": "v1"})
+			for _, v := range []string{"v2", "v3"} {
+				files := writeTree(t, src, map[string]string{"f.txt": v})
+				c := NewCopier(src, dst, false)
+				c.SetBackup(tt.opts)
+				for _, r := range runCopy(t, c, files) {
+					if !r.Success || r.Targets[0].BackupPath == "" {
+						t.Fatalf("copy %s: success=%v backup=%q (%v)", v, r.Success, r.Targets[0].BackupPath, r.Error)
+					}
+				}
+			}
+			if got := readFile(dst, "f.txt"); got != "v3" {
+				t.Errorf("target = %q, want v3", got)
+			}
+			for i, b := range tt.backups {
+				if got := readFile(dst, b); got != tt.olds[i] {
+					t.Errorf("%s = %q, want %q", b, got, tt.olds[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBackupTimestampName(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	files := writeTree(t, src, map[string]string{"f.txt": "new"})
+	writeTree(t, dst, map[string]string{"f.txt": "old"})
+
+	c := NewCopier(src, dst, false)
+	c.SetBackup(BackupOptions{Mode: BackupTimestamp})
+	r := runCopy(t, c, files)[0]
+	want := filepath.Join(dst, "f.txt."+c.startTime.Format("20060102-150405"))
+	if r.Targets[0].BackupPath != want || readFile(dst, filepath.Base(want)) != "old" {
+		t.Errorf("backup at %q, want %q holding the old content", r.Targets[0].BackupPath, want)
+	}
+}
+
+// When the new copy fails, the backed-up file is put back in place
+func TestBackupRestoredOnFailure(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	// 디렉터리는 열 수는 있지만 읽으면 실패하므로 백업 뒤 복사 단계에서 실패함
+	if err := os.MkdirAll(filepath.Join(src, "f.txt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTree(t, dst, map[string]string{"f.txt": "old"})
+
+	c := NewCopier(src, dst, false)
+	c.SetBackup(BackupOptions{Mode: BackupSuffix})
+	r := runCopy(t, c, []string{filepath.Join(src, "f.txt")})[0]
+	if r.Success {
+		t.Fatal("copy of an unreadable source succeeded")
+	}
+	if got := readFile(dst, "f.txt"); got != "old" {
+		t.Errorf("target = %q, want the restored old file", got)
+	}
+	if exists(dst, "f.txt~") {
+		t.Error("backup left behind after restore")
+	}
+}
@@ -0,0 +1,135 @@
+package copier
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CacheMode controls how bulk copies interact with the OS page cache
+type CacheMode int
+
+const (
+	CacheNormal   CacheMode = iota // 기본: 커널 페이지 캐시 그대로 사용
+	CacheDontNeed                  // 복사한 구간을 posix_fadvise(DONTNEED)로 캐시에서 제거
+	CacheDirect                    // O_DIRECT + 정렬 버퍼 (미지원 FS는 DONTNEED로 폴백)
+)
+
+const (
+	directAlign    = 4096             // O_DIRECT 정렬 단위
+	dropCacheChunk = 32 * 1024 * 1024 // DONTNEED 적용 주기
+)
+
+// errDirectUnsupported is returned when the filesystem rejects O_DIRECT
+var errDirectUnsupported = errors.New("O_DIRECT 미지원")
+
+// ParseCacheMode converts a flag value into a CacheMode
+func ParseCacheMode(name string) (CacheMode, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "normal":
+		return CacheNormal, nil
+	case "dontneed", "fadvise":
+		return CacheDontNeed, nil
+	case "direct":
+		return CacheDirect, nil
+	}
+	return CacheNormal, fmt.Errorf("알 수 없는 캐시 모드: %s (normal, dontneed, direct)", name)
+}
+
+// String returns the flag name of the cache mode
+func (m CacheMode) String() string {
+	switch m {
+	case CacheDontNeed:
+		return "dontneed"
+	case CacheDirect:
+		return "direct"
+	}
+	return "normal"
+}
+
+// SetCacheMode selects page-cache behaviour (call before CopyFilesParallel).
+// Only Linux honours it; other platforms copy normally.
+func (c *Copier) SetCacheMode(m CacheMode) { c.cacheMode = m }
+
+// CacheModeInEffect returns the mode actually used, reflecting O_DIRECT fallback
+func (c *Copier) CacheModeInEffect() CacheMode {
+	if c.cacheMode == CacheDirect && atomic.LoadInt32(&c.directFailed) == 1 {
+		return CacheDontNeed
+	}
+	return c.cacheMode
+}
+
+// newBuffer allocates a worker buffer, aligned when O_DIRECT may be used
+func (c *Copier) newBuffer(size int) []byte {
+	if c.cacheMode == CacheDirect {
+		return alignedBuffer(size, directAlign)
+	}
+	return make([]byte, size)
+}
+
+// copyFileDirect copies with O_DIRECT on both ends. The tail block is padded to
+// the alignment and the target truncated back to the real size afterwards.
+func (c *Copier) copyFileDirect(srcPath, dstPath string, buffer []byte, root string) error {
+	if len(buffer) < directAlign || len(buffer)%directAlign != 0 {
+		return errDirectUnsupported
+	}
+	opened := time.Now()
+	sourceFile, targetFile, err := openDirect(srcPath, dstPath)
+	c.metrics.open.ObserveSince(opened)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+	// 실패(취소 포함)하면 잘린 대상 파일을 남기지 않음 (닫은 뒤 삭제)
+	complete := false
+	defer func() {
+		if !complete {
+			_ = os.Remove(dstPath)
+		}
+	}()
+	defer targetFile.Close()
+
+	var written int64
+	for {
+		if !c.waitIfPaused() {
+			return ErrCanceled
+		}
+		readStart := time.Now()
+		n, rerr := io.ReadFull(sourceFile, buffer)
+		c.metrics.read.ObserveSince(readStart)
+		if n > 0 {
+			wn := (n + directAlign - 1) / directAlign * directAlign
+			clear(buffer[n:wn])
+			writeStart := time.Now()
+			_, werr := targetFile.Write(buffer[:wn])
+			c.metrics.write.ObserveSince(writeStart)
+			if werr != nil {
+				// 첫 쓰기에서 거부되면 FS가 O_DIRECT 쓰기를 지원하지 않는 것
+				if written == 0 && isDirectRejected(werr) {
+					return errDirectUnsupported
+				}
+				return fmt.Errorf("쓰기 실패: %w", werr)
+			}
+			written += int64(n)
+			atomic.AddInt64(&c.bytesCopied, int64(n))
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			if written == 0 && isDirectRejected(rerr) {
+				return errDirectUnsupported
+			}
+			return fmt.Errorf("읽기 실패: %w", rerr)
+		}
+	}
+	if err := targetFile.Truncate(written); err != nil {
+		return fmt.Errorf("크기 조정 실패: %w", err)
+	}
+	complete = true
+	return c.syncTarget(targetFile, dstPath, root)
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package copier
+
+import (
+	"errors"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// alignedBuffer returns a slice whose start address and length are multiples of align
+func alignedBuffer(size, align int) []byte {
+	size = (size + align - 1) / align * align
+	raw := make([]byte, size+align)
+	off := 0
+	if rem := int(uintptr(unsafe.Pointer(&raw[0])) & uintptr(align-1)); rem != 0 {
+		off = align - rem
+	}
+	return raw[off : off+size : off+size]
+}
+
+// openDirect opens source and target with O_DIRECT
+func openDirect(srcPath, dstPath string) (*os.File, *os.File, error) {
+	src, err := os.OpenFile(srcPath, os.O_RDONLY|unix.O_DIRECT, 0)
+	if err != nil {
+		if isDirectRejected(err) {
+			return nil, nil, errDirectUnsupported
+		}
+		return nil, nil, err
+	}
+	dst, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC|unix.O_DIRECT, 0666)
+	if err != nil {
+		src.Close()
+		if isDirectRejected(err) {
+			return nil, nil, errDirectUnsupported
+		}
+		return nil, nil, err
+	}
+	return src, dst, nil
+}
+
+// isDirectRejected reports whether err means the filesystem refuses O_DIRECT
+func isDirectRejected(err error) bool {
+	return errors.Is(err, unix.EINVAL) || errors.Is(err, unix.EOPNOTSUPP)
+}
+
+// adviseSequential hints the kernel that the file is read once, front to back
+func adviseSequential(f *os.File) {
+	_ = unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_SEQUENTIAL)
+}
+
+// dropCacheRange writes back the target range and evicts it (and the matching
+// source range) from the page cache
+func dropCacheRange(src, dst *os.File, off, n int64) {
+	if n <= 0 {
+		return
+	}
+	_ = unix.SyncFileRange(int(dst.Fd()), off, n,
+		unix.SYNC_FILE_RANGE_WAIT_BEFORE|unix.SYNC_FILE_RANGE_WRITE|unix.SYNC_FILE_RANGE_WAIT_AFTER)
+	_ = unix.Fadvise(int(dst.Fd()), off, n, unix.FADV_DONTNEED)
+	_ = unix.Fadvise(int(src.Fd()), off, n, unix.FADV_DONTNEED)
+}
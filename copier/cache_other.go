@@ -0,0 +1,22 @@
+//go:build !linux
+
+package copier
+
+import "os"
+
+// alignedBuffer returns a plain buffer; O_DIRECT is Linux-only
+func alignedBuffer(size, align int) []byte { return make([]byte, size) }
+
+// openDirect always reports O_DIRECT as unsupported outside Linux
+func openDirect(srcPath, dstPath string) (*os.File, *os.File, error) {
+	return nil, nil, errDirectUnsupported
+}
+
+// isDirectRejected is never true outside Linux
+func isDirectRejected(err error) bool { return false }
+
+// adviseSequential is a no-op outside Linux
+func adviseSequential(f *os.File) {}
+
+// dropCacheRange is a no-op outside Linux
+func dropCacheRange(src, dst *os.File, off, n int64) {}
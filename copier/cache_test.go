@@ -0,0 +1,84 @@
+package copier
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Every cache mode produces an identical copy, including sizes around the
+// O_DIRECT alignment where the tail block is padded and truncated back
+func TestCacheModesCopyContent(t *testing.T) {
+	sizes := []int{0, 1, directAlign - 1, directAlign, directAlign + 1, 3*64*1024 + 123}
+	for _, mode := range []CacheMode{CacheNormal, CacheDontNeed, CacheDirect} {
+		t.Run(mode.String(), func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "src")
+			tree := map[string]string{}
+			for _, n := range sizes {
+				tree[fmt.Sprintf("f%d.bin", n)] = string(deltaData(int64(n), n))
+			}
+			files := writeTree(t, src, tree)
+
+			c := NewCopier(src, filepath.Join(base, "dst"), false)
+			c.SetCacheMode(mode)
+			c.SetBufferSizeMB(1)
+			for _, r := range runCopy(t, c, files) {
+				if !r.Success {
+					t.Errorf("%s: %v", r.FilePath, r.Error)
+				}
+			}
+			for name, want := range tree {
+				if got := readFile(filepath.Join(base, "dst"), name); got != want {
+					t.Errorf("%s: copied %d bytes, want %d", name, len(got), len(want))
+				}
+			}
+			if got := c.CacheModeInEffect(); got != mode && !(mode == CacheDirect && got == CacheDontNeed) {
+				t.Errorf("mode in effect = %v", got)
+			}
+		})
+	}
+}
+
+func TestCopyFileDirectPadding(t *testing.T) {
+	dir := t.TempDir()
+	buffer := alignedBuffer(2*directAlign, directAlign)
+	for _, n := range []int{1, directAlign, 2*directAlign + 7} {
+		data := deltaData(int64(n), n)
+		srcPath := filepath.Join(dir, fmt.Sprintf("src%d", n))
+		dstPath := filepath.Join(dir, fmt.Sprintf("dst%d", n))
+		if err := os.WriteFile(srcPath, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		c := NewCopier(dir, dir, false)
+		err := c.copyFileDirect(srcPath, dstPath, buffer, dir)
+		if errors.Is(err, errDirectUnsupported) {
+			t.Skip("O_DIRECT not supported here")
+		}
+		if err != nil {
+			t.Fatalf("copyFileDirect(%d): %v", n, err)
+		}
+		if got, _ := os.ReadFile(dstPath); !bytes.Equal(got, data) {
+			t.Errorf("size %d: target has %d bytes", n, len(got))
+		}
+	}
+	// 정렬되지 않은 버퍼는 O_DIRECT 를 쓰지 않고 폴백을 요청
+	c := NewCopier(dir, dir, false)
+	if err := c.copyFileDirect(filepath.Join(dir, "src1"), filepath.Join(dir, "x"), make([]byte, 1000), dir); !errors.Is(err, errDirectUnsupported) {
+		t.Errorf("unaligned buffer: err = %v, want errDirectUnsupported", err)
+	}
+}
+
+func TestParseCacheMode(t *testing.T) {
+	for in, want := range map[string]CacheMode{"": CacheNormal, "normal": CacheNormal, "fadvise": CacheDontNeed, "DontNeed": CacheDontNeed, " direct ": CacheDirect} {
+		if got, err := ParseCacheMode(in); err != nil || got != want {
+			t.Errorf("ParseCacheMode(%q) = %v, %v", in, got, err)
+		}
+	}
+	if _, err := ParseCacheMode("mmap"); err == nil {
+		t.Error("unknown cache mode accepted")
+	}
+}
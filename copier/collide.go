@@ -0,0 +1,302 @@
+package copier
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CollisionPolicy decides what happens to a source file whose target name
+// is already taken by another source file (README and readme on a
+// case-insensitive target, NFC and NFD forms of a name, escaped names)
+type CollisionPolicy int
+
+const (
+	CollisionFail   CollisionPolicy = iota // 나중 파일을 실패로 보고 (기본)
+	CollisionRename                        // 나중 파일 이름에 " (1)" 등 접미사를 붙임
+	CollisionSkip                          // 나중 파일은 복사하지 않음
+)
+
+func (p CollisionPolicy) String() string {
+	switch p {
+	case CollisionRename:
+		return "rename"
+	case CollisionSkip:
+		return "skip"
+	}
+	return "fail"
+}
+
+// ParseCollisionPolicy converts a flag value into a CollisionPolicy
+func ParseCollisionPolicy(name string) (CollisionPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "fail":
+		return CollisionFail, nil
+	case "rename":
+		return CollisionRename, nil
+	case "skip":
+		return CollisionSkip, nil
+	}
+	return CollisionFail, fmt.Errorf("알 수 없는 이름 충돌 처리: %s (fail, rename, skip)", name)
+}
+
+// SetCollisionPolicy selects how target name collisions are resolved. The
+// first file in path order keeps the name; PlanNames applies the policy to
+// a whole file list before copying. Without PlanNames (CopyFromScanner)
+// the first file to arrive keeps it instead (call before copying)
+func (c *Copier) SetCollisionPolicy(p CollisionPolicy) { c.collisions = p }
+
+// ErrNameCollision marks a file whose target name is already used by
+// another source file
+var ErrNameCollision = errors.New("대상 이름 충돌")
+
+// RenameCollision is recorded in TargetResult.Renamed for files given a
+// suffix by CollisionRename
+const RenameCollision = "collision"
+
+// NameCollision is a source file whose target name was taken by another
+type NameCollision struct {
+	Root   string // 대상 루트
+	Source string // 충돌한 소스 상대 경로
+	Owner  string // 이름을 먼저 차지한 소스 상대 경로
+	Target string // 대상 경로 (rename 이면 접미사가 붙은 경로, skip/fail 이면 겹친 경로)
+}
+
+// nameClaim is how a source file's target name was resolved
+type nameClaim struct {
+	path  string // 최종 대상 경로
+	owner string // 이름이 겹친 소스 ("" 이면 충돌 없음)
+	skip  bool
+	err   error
+}
+
+// needsClaims reports whether two source names can meet on a target root
+func (c *Copier) needsClaims(root string) bool {
+	names := c.rootNames(root)
+	return names.foldCase || names.foldForm || c.namesMapped(root)
+}
+
+// foldKey is the name a target root uses to tell files apart
+func (c *Copier) foldKey(root, path string) string {
+	names := c.rootNames(root)
+	if names.foldForm {
+		path = formKey(path)
+	}
+	if names.foldCase {
+		path = foldCase(path)
+	}
+	return path
+}
+
+// foldCase upper-cases each rune the way case-insensitive filesystems compare
+// names; bytes that are not valid UTF-8 are kept
+func foldCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteByte(s[i])
+		} else {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// PlanNames claims the target names of a whole file list before copying so
+// collisions are found up front and resolved the same way on every run
+// (sorted path order: the first path keeps the name)
+func (c *Copier) PlanNames(files []string) []NameCollision {
+	var rels []string
+	for _, p := range files {
+		if rel, ok := c.relPathFor(p); ok {
+			rels = append(rels, rel)
+		}
+	}
+	sort.Strings(rels)
+	var found []NameCollision
+	for _, root := range c.TargetDirs() {
+		if !c.needsClaims(root) {
+			continue
+		}
+		for _, relPath := range rels {
+			rel, _ := c.targetRel(root, relPath)
+			res := c.claim(root, relPath, filepath.Join(root, rel))
+			if res.owner != "" {
+				found = append(found, NameCollision{Root: root, Source: relPath, Owner: res.owner, Target: res.path})
+			}
+		}
+	}
+	if len(found) > 0 {
+		c.log.Warn("대상 이름 충돌", "count", len(found), "policy", c.collisions.String())
+	}
+	return found
+}
+
+// claim records which source file owns a target name and applies the
+// collision policy when another source already owns it
+func (c *Copier) claim(root, relPath, path string) nameClaim {
+	c.claimMu.Lock()
+	defer c.claimMu.Unlock()
+	id := root + "\x00" + relPath
+	if res, ok := c.resolved[id]; ok {
+		return res
+	}
+	if c.claims == nil {
+		c.claims = make(map[string]string)
+		c.resolved = make(map[string]nameClaim)
+	}
+	key := c.foldKey(root, path)
+	owner, ok := c.claims[key]
+	if !ok || owner == relPath {
+		c.claims[key] = relPath
+		return nameClaim{path: path}
+	}
+	res := nameClaim{path: path, owner: owner}
+	switch c.collisions {
+	case CollisionSkip:
+		res.skip = true
+	case CollisionRename:
+		for n := 1; ; n++ {
+			candidate := withSuffix(path, n)
+			if k := c.foldKey(root, candidate); c.claims[k] == "" {
+				c.claims[k] = relPath
+				res.path = candidate
+				break
+			}
+		}
+	default:
+		res.err = fmt.Errorf("%w: %s 와(과) %s 가 같은 이름이 됨 (%s)", ErrNameCollision, owner, relPath, path)
+	}
+	c.log.Info("이름 충돌", "root", root, "source", relPath, "owner", owner, "policy", c.collisions.String(), "target", res.path)
+	c.resolved[id] = res
+	return res
+}
+
+// withSuffix inserts " (n)" before the extension: a.txt → a (1).txt
+func withSuffix(path string, n int) string {
+	dir, name := filepath.Split(path)
+	ext := filepath.Ext(name)
+	if ext == name {
+		// .bashrc 처럼 점으로 시작하는 이름은 확장자가 없는 것으로 봄
+		ext = ""
+	}
+	return filepath.Join(dir, fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext))
+}
+
+// claimTargets resolves each target's name against the files already
+// claimed: colliding targets fail, are skipped or get a suffixed path
+func (c *Copier) claimTargets(targets []TargetResult, relPath string) {
+	for i := range targets {
+		t := &targets[i]
+		if t.Error != nil || !c.needsClaims(t.Root) {
+			continue
+		}
+		rel, _ := c.targetRel(t.Root, relPath)
+		res := c.claim(t.Root, relPath, filepath.Join(t.Root, rel))
+		if res.owner == "" {
+			continue
+		}
+		t.Collision = res.owner
+		switch {
+		case res.err != nil:
+			t.Error = res.err
+		case res.skip:
+			t.Success, t.Unchanged = true, true
+		default:
+			t.Path = res.path
+			if t.Renamed == "" {
+				t.Renamed = RenameCollision
+			} else {
+				t.Renamed += "+" + RenameCollision
+			}
+		}
+	}
+}
+
+// releaseTargets drops the claims of a deleted source file. Targets whose
+// name belongs to another source are reported so they are not deleted;
+// renamed targets are pointed at the suffixed path that was written
+func (c *Copier) releaseTargets(targets []TargetResult, relPath string) []bool {
+	owned := make([]bool, len(targets))
+	for i := range targets {
+		t := &targets[i]
+		if !c.needsClaims(t.Root) {
+			continue
+		}
+		rel, _ := c.targetRel(t.Root, relPath)
+		key := c.foldKey(t.Root, filepath.Join(t.Root, rel))
+		id := t.Root + "\x00" + relPath
+		c.claimMu.Lock()
+		if res, ok := c.resolved[id]; ok {
+			delete(c.resolved, id)
+			if res.skip || res.err != nil {
+				owned[i] = true
+			} else {
+				delete(c.claims, c.foldKey(t.Root, res.path))
+				t.Path = res.path
+			}
+		} else if owner, ok := c.claims[key]; ok {
+			if owner == relPath {
+				delete(c.claims, key)
+			} else {
+				owned[i] = true
+			}
+		}
+		c.claimMu.Unlock()
+	}
+	return owned
+}
+
+// collisionDropped reports whether PlanNames decided not to write relPath
+// under root (skipped or failed collision)
+func (c *Copier) collisionDropped(root, relPath string) bool {
+	c.claimMu.Lock()
+	defer c.claimMu.Unlock()
+	res, ok := c.resolved[root+"\x00"+relPath]
+	return ok && (res.skip || res.err != nil)
+}
+
+// collisionSkipped reports whether a target was left out because another
+// source file owns its name (the source must then survive a move)
+func collisionSkipped(targets []TargetResult) bool {
+	for _, t := range targets {
+		if t.Collision != "" && t.Unchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// probeFolding creates a scratch file in dir and looks it up under another
+// case and normalisation form; when dir is not writable the platform
+// default is assumed
+func probeFolding(dir string, windowsFS bool) (foldCase, foldForm bool) {
+	base := fmt.Sprintf(".sfc-probe-%d-", os.Getpid())
+	name := filepath.Join(dir, base+"\u00e9")
+	f, err := os.OpenFile(normalizeLongPath(name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		switch runtime.GOOS {
+		case "windows":
+			return true, false
+		case "darwin":
+			return true, true
+		}
+		return windowsFS, false
+	}
+	f.Close()
+	defer os.Remove(normalizeLongPath(name))
+	_, err = os.Lstat(normalizeLongPath(filepath.Join(dir, strings.ToUpper(base)+"\u00e9")))
+	foldCase = err == nil
+	_, err = os.Lstat(normalizeLongPath(filepath.Join(dir, base+"e\u0301")))
+	foldForm = err == nil
+	return foldCase, foldForm
+}
@@ -0,0 +1,121 @@
+package copier
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// caseInsensitive makes the copier treat root as a case-insensitive target
+// (the test filesystem itself is case-sensitive)
+func caseInsensitive(c *Copier, root string) {
+	c.namesOnce.Do(c.resolveNameRules)
+	names := c.nameRules[root]
+	names.foldCase = true
+	c.nameRules[root] = names
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		path string
+		n    int
+		want string
+	}{
+		{"a.txt", 1, "a (1).txt"},
+		{"dir/README", 2, "dir/README (2)"},
+		{".bashrc", 1, ".bashrc (1)"},
+		{"x.tar.gz", 1, "x.tar (1).gz"},
+	}
+	for _, tt := range tests {
+		if got := withSuffix(filepath.FromSlash(tt.path), tt.n); got != filepath.FromSlash(tt.want) {
+			t.Errorf("withSuffix(%q, %d) = %q, want %q", tt.path, tt.n, got, tt.want)
+		}
+	}
+	if foldCase("Readme.MD") != foldCase("README.md") || foldCase("\xb0a") != "\xb0A" {
+		t.Error("foldCase does not fold like a case-insensitive filesystem")
+	}
+}
+
+// README and readme meet on a case-insensitive target: the first in path
+// order keeps the name and the other fails, gets a suffix or is skipped
+func TestCaseCollisionPolicies(t *testing.T) {
+	tests := []struct {
+		policy    CollisionPolicy
+		wantFiles map[string]string // 대상에 남아야 하는 파일
+		wantGone  []string
+	}{
+		{CollisionFail, map[string]string{"README": "upper", "docs/A.txt": "A"}, []string{"readme", "docs/a.txt"}},
+		{CollisionRename, map[string]string{"README": "upper", "readme (1)": "lower", "docs/A.txt": "A", "docs/a (1).txt": "a"}, []string{"readme", "docs/a.txt"}},
+		{CollisionSkip, map[string]string{"README": "upper", "docs/A.txt": "A"}, []string{"readme", "docs/a.txt", "readme (1)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			base := t.TempDir()
+			src, dst := filepath.Join(base, "src"), filepath.Join(base, "dst")
+			files := writeTree(t, src, map[string]string{
+				"README": "upper", "readme": "lower", "docs/A.txt": "A", "docs/a.txt": "a", "other.txt": "o",
+			})
+
+			c := NewCopier(src, dst, false)
+			c.SetCollisionPolicy(tt.policy)
+			caseInsensitive(c, dst)
+			found := c.PlanNames(files)
+			if len(found) != 2 {
+				t.Fatalf("PlanNames found %d collisions, want 2: %+v", len(found), found)
+			}
+			for _, nc := range found {
+				if foldCase(nc.Source) != foldCase(nc.Owner) || nc.Source < nc.Owner {
+					t.Errorf("collision %+v: later path should lose to the first", nc)
+				}
+			}
+
+			for _, r := range runCopy(t, c, files) {
+				rel, _ := filepath.Rel(src, r.FilePath)
+				loser := rel == "readme" || rel == filepath.FromSlash("docs/a.txt")
+				tr := r.Targets[0]
+				switch {
+				case !loser:
+					if !r.Success || tr.Collision != "" {
+						t.Errorf("%s: success=%v collision=%q, want a plain copy", rel, r.Success, tr.Collision)
+					}
+				case tt.policy == CollisionFail:
+					if r.Success || !errors.Is(r.Error, ErrNameCollision) {
+						t.Errorf("%s: %v, want a name collision", rel, r.Error)
+					}
+				case tt.policy == CollisionRename:
+					if !r.Success || tr.Renamed != RenameCollision {
+						t.Errorf("%s: success=%v renamed=%q", rel, r.Success, tr.Renamed)
+					}
+				case tt.policy == CollisionSkip:
+					if !r.Success || !tr.Unchanged {
+						t.Errorf("%s: success=%v unchanged=%v, want skipped", rel, r.Success, tr.Unchanged)
+					}
+				}
+			}
+			for rel, content := range tt.wantFiles {
+				if got := readFile(dst, rel); got != content {
+					t.Errorf("%s = %q, want %q", rel, got, content)
+				}
+			}
+			for _, rel := range tt.wantGone {
+				if exists(dst, rel) {
+					t.Errorf("%s written to the target", rel)
+				}
+			}
+			if readFile(dst, "other.txt") != "o" {
+				t.Error("unrelated file not copied")
+			}
+		})
+	}
+}
+
+func TestParseCollisionPolicy(t *testing.T) {
+	for name, want := range map[string]CollisionPolicy{"": CollisionFail, "fail": CollisionFail, "Rename": CollisionRename, "skip": CollisionSkip} {
+		if got, err := ParseCollisionPolicy(name); err != nil || got != want {
+			t.Errorf("ParseCollisionPolicy(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseCollisionPolicy("overwrite"); err == nil {
+		t.Error("unknown policy accepted")
+	}
+}
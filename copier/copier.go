@@ -1,9 +1,12 @@
 package copier
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -11,8 +14,16 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"golang.org/x/text/encoding"
+
+	"superfast-copy-util/logging"
+	"superfast-copy-util/scanner"
 )
 
+// ErrCanceled is the error of files abandoned because the job was canceled
+var ErrCanceled = errors.New("사용자 취소")
+
 // CopyProgress represents the copy progress
 type CopyProgress struct {
 	CompletedFiles int64
@@ -25,6 +36,15 @@ type CopyProgress struct {
 	Speed          float64 // files per second
 	ElapsedTime    time.Duration
 	RemainingTime  time.Duration
+	Paused         bool
+	SyncedFiles    int64 // fsync 까지 마친 파일 수 (file/dir 내구성)
+	BatchSynced    bool  // batch 내구성: 종료 시 파일시스템 동기화 성공
+	TotalsFinal    bool  // 총계 확정 여부 (스트리밍 중에는 스캔이 끝날 때까지 false)
+	LinkedFiles    int64 // 이전 스냅숏에서 하드 링크로 처리한 파일 수
+	LinkedSize     int64 // 하드 링크로 절약한 바이트
+	DeltaFiles     int64 // 델타 전송으로 갱신한 파일 수
+	DeltaReused    int64 // 델타 전송에서 기존 대상 블록을 재사용한 바이트
+	RemovedFiles   int64 // 소스에서 사라져 대상에서도 지운 경로 수 (감시 모드)
 }
 
 // CopyResult represents the result of a file copy operation
@@ -33,6 +53,16 @@ type CopyResult struct {
 	Success  bool
 	Error    error
 	Size     int64
+	Synced   bool           // 성공 보고 전에 fsync 됨
+	Moved    bool           // 이동 모드: 소스가 제거됨
+	Targets  []TargetResult // 대상(루트)별 결과
+	Linked   bool           // 모든 대상이 이전 스냅숏의 하드 링크로 처리됨
+	Delta    bool           // 델타 전송으로 기존 대상을 갱신함
+	Reused   int64          // 델타 전송: 다시 쓰지 않고 재사용한 바이트
+	Skipped  bool           // 이미 최신이라 복사/전송하지 않음
+	Removed  bool           // 소스에서 사라진 경로를 대상에서 삭제함
+	Digest   string         // 검증 시 계산한 소스 SHA-256 (hex, 검증하지 않으면 빈 값)
+	Duration time.Duration  // 이 파일 처리에 걸린 시간
 }
 
 // Copier handles file copying operations
@@ -49,7 +79,89 @@ type Copier struct {
 	startTime    time.Time
 	tickInterval time.Duration
 	canceled     int32
-	bufferSize   int // per-worker buffer size in bytes
+	stopping     int32 // atomic: Stop 호출됨 (진행 중인 파일만 마무리)
+	bufferSize   int   // per-worker buffer size in bytes
+
+	// 일시정지 상태 (paused는 atomic, 나머지는 pauseMu 보호)
+	paused      int32
+	pauseMu     sync.Mutex
+	pauseCond   *sync.Cond
+	pausedAt    time.Time
+	pausedTotal time.Duration
+
+	// 적응형 워커/버퍼 조정 (autotune.go)
+	autoTune      bool
+	minWorkers    int
+	maxWorkers    int
+	activeWorkers int32 // atomic: id >= activeWorkers 인 워커는 대기
+	drained       int32 // atomic: 작업 채널이 닫히고 비어 대기 워커 종료
+	activeBuffer  int64 // atomic: 워커가 다음 파일부터 사용할 버퍼 크기
+	bytesCopied   int64 // atomic: 버퍼 단위 누적 복사 바이트
+	tuneMu        sync.Mutex
+	tuneLog       []TuneEvent
+
+	// 작업 큐 순서 (schedule.go)
+	schedule       Schedule
+	largeThreshold int64
+
+	// 페이지 캐시 모드 (cache.go)
+	cacheMode    CacheMode
+	directFailed int32 // atomic: O_DIRECT 거부 후 폴백
+
+	// 내구성 수준 (durability.go)
+	durability   Durability
+	syncedDirs   sync.Map // 이미 동기화한 상위 디렉터리
+	batchSyncErr error
+
+	// 이동 모드 (move.go)
+	moveMode  bool
+	verify    bool
+	movedDirs sync.Map // 이번 실행에서 파일을 옮겨 낸 소스 디렉터리
+
+	// 추가 대상 루트 (fanout.go)
+	extraTargets []string
+
+	// 덮어쓰기 전 백업 (backup.go)
+	backup BackupOptions
+
+	// 스냅숏 하드 링크 (linkdest.go)
+	linkDest      string
+	preserveTimes bool
+
+	// 델타 전송 (delta.go)
+	deltaThreshold int64
+
+	// 원격 전송 (transport.go)
+	transport Transport
+
+	// 로그 (log.go)
+	log *slog.Logger
+
+	// 메트릭 (metrics.go)
+	metrics     copyMetrics
+	busyWorkers int32         // atomic: 파일을 처리 중인 워커 수
+	queues      []chan string // 현재 실행의 작업 채널 (progressMux 보호)
+
+	// 변경분 동기화 (mirror.go)
+	conflict      ConflictPolicy
+	mirrorDeletes bool
+
+	// 대상 파일 이름 규칙 (names.go)
+	targetFS  TargetFS
+	namesOnce sync.Once
+	nameRules map[string]rootNames
+	norm      NameNorm
+	formMu    sync.Mutex
+	formDirs  map[string]map[string][]string // 디렉터리 → 정규화 키 → 실제 이름
+	nameEnc   encoding.Encoding              // UTF-8 이 아닌 소스 이름의 인코딩 (transcode.go)
+
+	// 이름 충돌 (collide.go)
+	collisions CollisionPolicy
+	claimMu    sync.Mutex
+	claims     map[string]string    // 비교 키 → 그 대상 이름을 쓰는 소스 상대 경로
+	resolved   map[string]nameClaim // 루트+소스 상대 경로 → 충돌 처리 결과
+
+	filter scanner.Filter // 대상 디렉터리를 미리 만들 때 제외할 경로
 }
 
 // NewCopier creates a new Copier instance
@@ -64,7 +176,7 @@ func NewCopier(sourceDir, targetDir string, useAPFSClone bool) *Copier {
 		tickMs = 100
 	}
 
-	return &Copier{
+	c := &Copier{
 		sourceDir:    sourceDir,
 		targetDir:    targetDir,
 		progressCh:   make(chan CopyProgress, 100),
@@ -75,7 +187,10 @@ func NewCopier(sourceDir, targetDir string, useAPFSClone bool) *Copier {
 		startTime:    time.Now(),
 		tickInterval: time.Duration(tickMs) * time.Millisecond,
 		bufferSize:   1 * 1024 * 1024, // default 1MB
+		log:          logging.Discard(),
 	}
+	c.pauseCond = sync.NewCond(&c.pauseMu)
+	return c
 }
 
 // Close closes all channels
@@ -100,64 +215,150 @@ func (c *Copier) CopyFilesParallel(files []string) {
 		c.progressMux.Lock()
 		c.progress.TotalFiles = int64(len(files))
 		c.progress.TotalSize = 0
+		c.progress.TotalsFinal = true
 		c.progressMux.Unlock()
 		// debug removed
 
+		// 스케줄 전략에 따라 순서 결정 (two-lane이면 큰 파일 목록 분리)
+		files, largeFiles := c.orderFiles(files)
+
 		// 파일 채널 생성: 과도한 버퍼 사용을 피하기 위해 상한 적용
 		bufCap := len(files)
 		if bufCap > 8192 {
 			bufCap = 8192
 		}
-		fileChan := make(chan string, bufCap)
-		var wg sync.WaitGroup
-
-		// debug removed
+		c.runWorkers(bufCap, largeFiles, func(fileChan chan<- string) {
+			for _, file := range files {
+				fileChan <- file
+			}
+		})
+		c.finishRun()
+	}()
+}
 
-		// 워커들 시작
-		for i := 0; i < c.workerCount; i++ {
-			wg.Add(1)
-			go c.copyWorker(fileChan, &wg)
-		}
+// CopyFromScanner copies files as the scanner discovers them (pipelined mode):
+// copying starts immediately and totals/ETA grow until the scan finishes.
+// Only discovery order applies here since the full list is never known:
+// there is no Preflight, and PlanNames cannot run, so a target name
+// collision is resolved in arrival order (the first file found keeps it).
+func (c *Copier) CopyFromScanner(files <-chan scanner.FileInfo) {
+	go func() {
+		defer c.Close()
 
-		// debug removed
+		// 디렉터리 생성은 복사와 동시에 진행 (워커도 필요한 경로는 직접 생성)
+		dirsDone := make(chan struct{})
+		go func() {
+			c.ensureAllDirectories()
+			close(dirsDone)
+		}()
 
-		// 진행 상황 모니터링
-		done := make(chan bool)
-		go c.monitorProgress(done)
-		// 초기 진행 상태를 즉시 1회 전송하여 "복사 중" 첫 줄이 곧바로 표시되도록 함
-		func() {
+		c.runWorkers(8192, nil, func(fileChan chan<- string) {
+			for f := range files {
+				c.progressMux.Lock()
+				c.progress.TotalFiles++
+				c.progress.TotalSize += f.Size
+				c.progressMux.Unlock()
+				fileChan <- f.Path
+			}
+			// 스캔 종료: 이제 총계가 확정됨
 			c.progressMux.Lock()
-			p := c.progress
+			c.progress.TotalsFinal = true
 			c.progressMux.Unlock()
-			select {
-			case c.progressCh <- p:
-			default:
-			}
-		}()
+		})
+		<-dirsDone
+		c.finishRun()
+	}()
+}
 
-		// 파일들을 채널에 전송
-		for _, file := range files {
-			fileChan <- file
-		}
-		close(fileChan)
+// runWorkers starts the workers and progress monitors, lets feed push files
+// into the queue and waits until every queued file has been processed
+func (c *Copier) runWorkers(bufCap int, largeFiles []string, feed func(fileChan chan<- string)) {
+	fileChan := make(chan string, bufCap)
+	var largeChan chan string
+	var wg sync.WaitGroup
+
+	c.logRunStart()
 
-		// 모든 워커 완료 대기
-		wg.Wait()
-		close(done)
+	// 워커들 시작 (자동 조정 시 최대치까지 띄우고 활성 수로 제한)
+	c.initWorkerGate()
+	spawn := c.spawnCount()
+	if c.schedule == ScheduleTwoLane && largeFiles != nil {
+		largeChan = make(chan string, len(largeFiles))
+	}
+	c.setQueues(fileChan, largeChan)
+	for i := 0; i < spawn; i++ {
+		wg.Add(1)
+		if largeChan != nil && largeLaneWorker(i) {
+			// 큰 파일 전용 워커: 큰 파일이 끝나면 작은 파일 처리에 합류
+			go c.copyWorker(i, &wg, largeChan, fileChan)
+		} else {
+			go c.copyWorker(i, &wg, fileChan)
+		}
+	}
 
-		// 최종 진행 상황 전송
-		c.sendFinalProgress()
+	// 진행 상황 모니터링
+	done := make(chan bool)
+	go c.monitorProgress(done)
+	if c.autoTune {
+		go c.autoTuneLoop(done)
+	}
+	// 초기 진행 상태를 즉시 1회 전송하여 "복사 중" 첫 줄이 곧바로 표시되도록 함
+	func() {
+		c.progressMux.Lock()
+		p := c.progress
+		c.progressMux.Unlock()
+		select {
+		case c.progressCh <- p:
+		default:
+		}
 	}()
+
+	// 파일들을 채널에 전송
+	if largeChan != nil {
+		for _, file := range largeFiles {
+			largeChan <- file
+		}
+		close(largeChan)
+	}
+	feed(fileChan)
+	close(fileChan)
+
+	// 모든 워커 완료 대기
+	wg.Wait()
+	close(done)
+	c.setQueues()
+}
+
+// finishRun performs end-of-job steps and sends the final progress
+func (c *Copier) finishRun() {
+	// batch 내구성: 마지막에 대상 파일시스템을 한 번에 동기화 (원격 대상 제외)
+	if c.durability == DurabilityBatch && !c.remotePush() {
+		c.batchSync()
+	}
+
+	// 이동 모드: 비워진 소스 디렉터리 정리
+	if c.moveMode && atomic.LoadInt32(&c.canceled) == 0 {
+		c.pruneSourceDirs()
+	}
+
+	// 최종 진행 상황 전송
+	c.sendFinalProgress()
+	c.logRunEnd()
 }
 
-// ensureAllDirectories walks the source tree and creates corresponding directories in the target,
-// so that empty directories are preserved.
+// ensureAllDirectories walks the source tree and creates corresponding directories in every
+// target, so that empty directories are preserved.
 func (c *Copier) ensureAllDirectories() {
+	// 원격 전송은 소스 또는 대상이 로컬 트리가 아님 (필요한 경로는 전송 시 생성)
+	if c.transport != nil {
+		return
+	}
 	// 소스 루트가 없으면 스킵
 	srcInfo, err := os.Stat(c.sourceDir)
 	if err != nil || !srcInfo.IsDir() {
 		return
 	}
+	roots := c.TargetDirs()
 	// 루트도 포함해 순회하며 디렉터리만 생성
 	_ = filepath.WalkDir(c.sourceDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -169,37 +370,78 @@ func (c *Copier) ensureAllDirectories() {
 			if rErr != nil {
 				return nil
 			}
-			dst := filepath.Join(c.targetDir, rel)
-			// 빈 문자열(rel==".")이면 타겟 루트 자체
-			if rel == "." {
-				dst = c.targetDir
+			// 제외된 디렉터리는 만들지도 내려가지도 않음
+			if rel != "." && !c.filter.Match(rel, true) {
+				return filepath.SkipDir
+			}
+			for _, root := range roots {
+				// rel=="."이면 타겟 루트 자체
+				_ = os.MkdirAll(c.TargetPath(root, rel), 0755)
 			}
-			_ = os.MkdirAll(dst, 0755)
 		}
 		return nil
 	})
 }
 
-// copyWorker is a worker goroutine that copies files
-func (c *Copier) copyWorker(fileChan <-chan string, wg *sync.WaitGroup) {
+// copyWorker is a worker goroutine that copies files, draining lanes in order
+func (c *Copier) copyWorker(id int, wg *sync.WaitGroup, lanes ...<-chan string) {
 	defer wg.Done()
-	bufSize := c.bufferSize
-	if bufSize <= 0 {
-		bufSize = 1 * 1024 * 1024
-	}
-	buffer := make([]byte, bufSize)
+	buffer := c.newBuffer(c.currentBufferSize())
+	workerBytes := c.workerCounter(id)
 
-	for srcPath := range fileChan {
-		if atomic.LoadInt32(&c.canceled) == 1 {
+	for len(lanes) > 0 {
+		// 일시정지 또는 비활성(자동 조정으로 축소된) 워커는 여기서 대기
+		if !c.waitTurn(id) {
+			// 취소: 남은 작업은 버려서 파일 투입 쪽이 막히지 않게 함
+			for _, lane := range lanes {
+				for range lane {
+				}
+			}
 			return
 		}
+		srcPath, ok := <-lanes[0]
+		if !ok {
+			lanes = lanes[1:]
+			if len(lanes) == 0 {
+				// 마지막 채널까지 비었으면 대기 중인 워커도 종료
+				c.releaseParked()
+			}
+			continue
+		}
+		// 자동 조정으로 버퍼 크기가 바뀌었으면 다음 파일부터 반영
+		if want := c.currentBufferSize(); len(buffer) != want {
+			buffer = c.newBuffer(want)
+		}
+		started := time.Now()
+		atomic.AddInt32(&c.busyWorkers, 1)
 		result := c.copySingleFile(srcPath, buffer)
+		atomic.AddInt32(&c.busyWorkers, -1)
+		result.Duration = time.Since(started)
+		workerBytes.Add(result.CopiedBytes())
+		c.logResult(result)
 		c.resultCh <- result
 
 		if result.Success {
 			c.progressMux.Lock()
 			c.progress.CompletedFiles++
 			c.progress.CompletedSize += result.Size
+			if result.Synced {
+				c.progress.SyncedFiles++
+			}
+			if result.Linked {
+				c.progress.LinkedFiles++
+				c.progress.LinkedSize += result.Size
+			}
+			if result.Skipped {
+				c.progress.SkippedFiles++
+			}
+			if result.Removed {
+				c.progress.RemovedFiles++
+			}
+			if result.Delta {
+				c.progress.DeltaFiles++
+				c.progress.DeltaReused += result.Reused
+			}
 			c.progressMux.Unlock()
 		} else {
 			c.progressMux.Lock()
@@ -209,7 +451,7 @@ func (c *Copier) copyWorker(fileChan <-chan string, wg *sync.WaitGroup) {
 	}
 }
 
-// copySingleFile copies a single file
+// copySingleFile copies a single file to every target root
 func (c *Copier) copySingleFile(srcPath string, buffer []byte) CopyResult {
 	// 상대 경로 계산은 원본 경로로 수행(긴 경로 접두 제거)
 	origSrc := filepath.Clean(srcPath)
@@ -222,22 +464,38 @@ func (c *Copier) copySingleFile(srcPath string, buffer []byte) CopyResult {
 			return CopyResult{
 				FilePath: origSrc,
 				Success:  false,
-				Error:    fmt.Errorf("상대 경로 계산 실패: %v", err),
+				Error:    fmt.Errorf("상대 경로 계산 실패: %w", err),
 			}
 		}
 	}
 
-	dstPath := filepath.Join(c.targetDir, relPath)
+	if c.transport != nil {
+		return c.copyViaTransport(origSrc, relPath, buffer)
+	}
+
 	longSrc := normalizeLongPath(origSrc)
-	longDst := normalizeLongPath(dstPath)
-	dstDir := filepath.Dir(dstPath)
+	// 감시 모드: 이미 사라진 소스는 대상에서도 삭제
+	if c.mirrorDeletes && sourceGone(longSrc) {
+		return c.removeTargets(origSrc, relPath)
+	}
+	targets := c.newTargetResults(relPath, true)
+	c.claimTargets(targets, relPath)
 
-	// 대상 디렉토리 생성
-	if err := os.MkdirAll(dstDir, 0755); err != nil {
+	// 대상 디렉토리 생성 (실패한 대상만 제외하고 계속)
+	for i := range targets {
+		if targets[i].Error != nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(targets[i].Path), 0755); err != nil {
+			targets[i].Error = fmt.Errorf("디렉토리 생성 실패: %w", err)
+		}
+	}
+	if liveTargets(targets) == 0 {
 		return CopyResult{
 			FilePath: srcPath,
 			Success:  false,
-			Error:    fmt.Errorf("디렉토리 생성 실패: %v", err),
+			Error:    firstTargetError(targets),
+			Targets:  targets,
 		}
 	}
 
@@ -247,17 +505,150 @@ func (c *Copier) copySingleFile(srcPath string, buffer []byte) CopyResult {
 		return CopyResult{
 			FilePath: origSrc,
 			Success:  false,
-			Error:    fmt.Errorf("파일 정보 읽기 실패: %v", err),
+			Error:    fmt.Errorf("파일 정보 읽기 실패: %w", err),
+			Targets:  targets,
 		}
 	}
 
-	// 파일 복사
-	if err := c.copyFileContent(longSrc, longDst, buffer); err != nil {
+	// 이미 같은 대상은 건너뜀
+	c.markUnchanged(targets, info)
+
+	// 기존 대상 파일 백업 (실패하거나 건너뛴 대상은 덮어쓰지 않음)
+	for i := range targets {
+		if targets[i].Error != nil || targets[i].Success {
+			continue
+		}
+		backupPath, err := c.backupExisting(targets[i], relPath)
+		if err != nil {
+			targets[i].Error = err
+			continue
+		}
+		targets[i].BackupPath = backupPath
+	}
+	if liveTargets(targets) == 0 {
 		return CopyResult{
 			FilePath: origSrc,
 			Success:  false,
+			Error:    firstTargetError(targets),
+			Size:     info.Size(),
+			Targets:  targets,
+		}
+	}
+
+	// 이전 스냅숏과 같은 파일은 하드 링크로 처리
+	c.linkUnchanged(targets, relPath, info)
+
+	// 이동 모드: 대상이 하나이고 같은 파일시스템이면 rename 한 번으로 끝
+	if c.moveMode && len(targets) == 1 && !targets[0].Success && c.tryRename(longSrc, normalizeLongPath(targets[0].Path)) {
+		// 이미 옮겨졌으므로 동기화 실패는 실패로 보고하되 Moved 는 유지
+		err := c.syncRenamed(longSrc, normalizeLongPath(targets[0].Path), targets[0].Root)
+		targets[0].Success, targets[0].Error = err == nil, err
+		c.noteMoved(origSrc)
+		return CopyResult{
+			FilePath: origSrc,
+			Success:  err == nil,
 			Error:    err,
 			Size:     info.Size(),
+			Synced:   err == nil && (c.durability == DurabilityFile || c.durability == DurabilityFileDir),
+			Moved:    true,
+			Targets:  targets,
+		}
+	}
+
+	// 큰 파일의 이전 버전이 대상에 있으면 바뀐 블록만 기록
+	delta := false
+	var reused int64
+	if c.useDelta(targets, info) {
+		n, err := c.copyFileDelta(longSrc, &targets[0], buffer)
+		switch {
+		case err == nil:
+			targets[0].Success = true
+			delta, reused = true, n
+		case errors.Is(err, ErrCanceled):
+			targets[0].Error = err
+		}
+		// 그 밖의 실패는 아래 전체 복사로 폴백
+	}
+
+	// 파일 복사 (한 번 읽어서 모든 대상에 기록, 링크된 대상 제외)
+	if pendingTargets(targets) > 0 {
+		c.copyFileContent(longSrc, targets, buffer)
+	}
+
+	// 검증: 실패한 대상은 지우고 소스는 그대로 둠
+	digest := ""
+	if c.verify {
+		for i := range targets {
+			if !targets[i].Success || targets[i].Linked || targets[i].Unchanged {
+				continue
+			}
+			longDst := normalizeLongPath(targets[i].Path)
+			sum, err := verifyCopy(longSrc, longDst, buffer)
+			if err != nil {
+				_ = os.Remove(longDst)
+				targets[i].Success = false
+				targets[i].Error = err
+				continue
+			}
+			digest = hex.EncodeToString(sum)
+		}
+	}
+
+	c.applyTimes(targets, info)
+
+	if err := firstTargetError(targets); err != nil {
+		// 새 복사본이 실패한 대상은 백업해 둔 기존 파일을 되돌림
+		for i := range targets {
+			if !targets[i].Success && targets[i].BackupPath != "" {
+				restoreBackup(targets[i])
+				targets[i].BackupPath = ""
+			}
+		}
+		return CopyResult{
+			FilePath: origSrc,
+			Success:  false,
+			Error:    err,
+			Size:     info.Size(),
+			Targets:  targets,
+		}
+	}
+
+	synced := c.durability == DurabilityFile || c.durability == DurabilityFileDir
+	// 이름 충돌로 건너뛴 대상이 있으면 소스가 유일한 사본이므로 남김
+	if c.moveMode && !collisionSkipped(targets) {
+		// 이번에 쓰지 않은 대상은 내용이 같을 때만 소스 삭제
+		if err := confirmKept(longSrc, targets, buffer); err != nil {
+			return CopyResult{
+				FilePath: origSrc,
+				Success:  false,
+				Error:    err,
+				Size:     info.Size(),
+				Targets:  targets,
+			}
+		}
+		// 모든 대상에 성공한 경우에만 소스 삭제
+		if err := c.finishMove(longSrc, targets); err != nil {
+			return CopyResult{
+				FilePath: origSrc,
+				Success:  false,
+				Error:    err,
+				Size:     info.Size(),
+				Targets:  targets,
+			}
+		}
+		c.noteMoved(origSrc)
+		return CopyResult{
+			FilePath: origSrc,
+			Success:  true,
+			Size:     info.Size(),
+			Synced:   synced,
+			Moved:    true,
+			Targets:  targets,
+			Linked:   allLinked(targets),
+			Delta:    delta,
+			Reused:   reused,
+			Skipped:  allUnchanged(targets),
+			Digest:   digest,
 		}
 	}
 
@@ -265,6 +656,13 @@ func (c *Copier) copySingleFile(srcPath string, buffer []byte) CopyResult {
 		FilePath: origSrc,
 		Success:  true,
 		Size:     info.Size(),
+		Synced:   synced,
+		Targets:  targets,
+		Linked:   allLinked(targets),
+		Delta:    delta,
+		Reused:   reused,
+		Skipped:  allUnchanged(targets),
+		Digest:   digest,
 	}
 }
 
@@ -292,7 +690,12 @@ func (c *Copier) relPathFallback(srcPath string) (string, bool) {
 }
 
 // WorkerCount returns current worker count (for diagnostics)
-func (c *Copier) WorkerCount() int { return c.workerCount }
+func (c *Copier) WorkerCount() int {
+	if n := atomic.LoadInt32(&c.activeWorkers); n > 0 {
+		return int(n)
+	}
+	return c.workerCount
+}
 
 // normalizeLongPath converts a Windows path to long-path form when necessary
 func normalizeLongPath(p string) string {
@@ -309,39 +712,135 @@ func normalizeLongPath(p string) string {
 	return `\\?\` + p
 }
 
-// copyFileContent copies the content of a file
-func (c *Copier) copyFileContent(srcPath, dstPath string, buffer []byte) error {
+// copyFileContent copies the content of a file to every live target; a target
+// that fails is dropped (its partial file removed) while the others continue
+func (c *Copier) copyFileContent(srcPath string, targets []TargetResult, buffer []byte) {
+	// O_DIRECT 모드: 거부되면 이후 파일은 DONTNEED 모드로 폴백 (단일 대상만)
+	if c.cacheMode == CacheDirect && len(targets) == 1 && !targets[0].Success && atomic.LoadInt32(&c.directFailed) == 0 {
+		// 스냅숏과 공유하는 하드 링크는 잘라 쓰지 않고 끊은 뒤 새로 만듦
+		err := unshareTarget(normalizeLongPath(targets[0].Path))
+		if err == nil {
+			err = c.copyFileDirect(srcPath, normalizeLongPath(targets[0].Path), buffer, targets[0].Root)
+		}
+		if !errors.Is(err, errDirectUnsupported) {
+			targets[0].Error = err
+			targets[0].Success = err == nil
+			return
+		}
+		atomic.StoreInt32(&c.directFailed, 1)
+	}
+	dropCache := c.cacheMode != CacheNormal
+
+	opened := time.Now()
 	sourceFile, err := os.Open(srcPath)
+	c.metrics.open.ObserveSince(opened)
 	if err != nil {
-		return fmt.Errorf("소스 파일 열기 실패: %v", err)
+		failTargets(targets, fmt.Errorf("소스 파일 열기 실패: %w", err))
+		return
 	}
 	defer sourceFile.Close()
 
-	targetFile, err := os.Create(dstPath)
-	if err != nil {
-		return fmt.Errorf("대상 파일 생성 실패: %v", err)
+	files := make([]*os.File, len(targets))
+	for i := range targets {
+		if targets[i].Error != nil || targets[i].Success {
+			continue
+		}
+		if err := unshareTarget(normalizeLongPath(targets[i].Path)); err != nil {
+			targets[i].Error = err
+			continue
+		}
+		opened := time.Now()
+		f, err := os.Create(normalizeLongPath(targets[i].Path))
+		c.metrics.open.ObserveSince(opened)
+		if err != nil {
+			targets[i].Error = fmt.Errorf("대상 파일 생성 실패: %w", err)
+			continue
+		}
+		files[i] = f
+	}
+	// 실패로 닫힌 대상은 부분 파일 제거
+	dropTarget := func(i int, err error) {
+		targets[i].Error = err
+		files[i].Close()
+		_ = os.Remove(normalizeLongPath(targets[i].Path))
+		files[i] = nil
+	}
+	// 취소/읽기 실패: 쓰던 대상 모두 부분 파일을 지우고 실패 처리
+	abort := func(err error) {
+		for i, f := range files {
+			if f != nil {
+				dropTarget(i, err)
+			}
+		}
+		failTargets(targets, err)
 	}
-	defer targetFile.Close()
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+			}
+		}
+	}()
 
-	for {
-		if atomic.LoadInt32(&c.canceled) == 1 {
-			return fmt.Errorf("사용자 취소")
+	if dropCache {
+		adviseSequential(sourceFile)
+	}
+	var offset, dropped int64
+	for pendingTargets(targets) > 0 {
+		// 버퍼 단위 사이가 안전 지점: 일시정지 시 여기서 대기
+		if !c.waitIfPaused() {
+			abort(ErrCanceled)
+			return
 		}
+		readStart := time.Now()
 		n, rerr := sourceFile.Read(buffer)
+		c.metrics.read.ObserveSince(readStart)
 		if n > 0 {
-			if _, werr := targetFile.Write(buffer[:n]); werr != nil {
-				return fmt.Errorf("쓰기 실패: %v", werr)
+			for i, f := range files {
+				if f == nil {
+					continue
+				}
+				writeStart := time.Now()
+				_, werr := f.Write(buffer[:n])
+				c.metrics.write.ObserveSince(writeStart)
+				if werr != nil {
+					dropTarget(i, fmt.Errorf("쓰기 실패: %w", werr))
+				}
+			}
+			atomic.AddInt64(&c.bytesCopied, int64(n))
+			offset += int64(n)
+			// 일정량마다 기록 완료된 구간을 캐시에서 제거
+			if dropCache && offset-dropped >= dropCacheChunk {
+				for _, f := range files {
+					if f != nil {
+						dropCacheRange(sourceFile, f, dropped, offset-dropped)
+					}
+				}
+				dropped = offset
 			}
 		}
 		if rerr == io.EOF {
 			break
 		}
 		if rerr != nil {
-			return fmt.Errorf("읽기 실패: %v", rerr)
+			abort(fmt.Errorf("읽기 실패: %w", rerr))
+			return
 		}
 	}
 
-	return nil
+	for i, f := range files {
+		if f == nil {
+			continue
+		}
+		if dropCache {
+			dropCacheRange(sourceFile, f, dropped, offset-dropped)
+		}
+		if err := c.syncTarget(f, normalizeLongPath(targets[i].Path), targets[i].Root); err != nil {
+			targets[i].Error = err
+			continue
+		}
+		targets[i].Success = true
+	}
 }
 
 // monitorProgress monitors and reports copy progress
@@ -362,9 +861,10 @@ func (c *Copier) monitorProgress(done <-chan bool) {
 			progress := c.progress
 			c.progressMux.Unlock()
 
-			// 시간 정보 업데이트
-			elapsed := time.Since(c.startTime)
+			// 시간 정보 업데이트 (일시정지 시간 제외)
+			elapsed := c.elapsed()
 			progress.ElapsedTime = elapsed
+			progress.Paused = c.IsPaused()
 
 			// 속도 계산
 			if elapsed.Seconds() > 0 {
@@ -393,7 +893,7 @@ func (c *Copier) sendFinalProgress() {
 	progress := c.progress
 	c.progressMux.Unlock()
 
-	elapsed := time.Since(c.startTime)
+	elapsed := c.elapsed()
 	progress.ElapsedTime = elapsed
 
 	if elapsed.Seconds() > 0 {
@@ -406,6 +906,35 @@ func (c *Copier) sendFinalProgress() {
 	}
 }
 
+// batchSync flushes the target filesystems once at the end (batch durability)
+func (c *Copier) batchSync() {
+	var errs []error
+	for _, root := range c.TargetDirs() {
+		if err := syncFilesystem(normalizeLongPath(root)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", root, err))
+		}
+	}
+	err := errors.Join(errs...)
+	c.progressMux.Lock()
+	c.batchSyncErr = err
+	c.progress.BatchSynced = err == nil
+	c.progressMux.Unlock()
+	if err != nil {
+		c.log.Error("파일시스템 동기화 실패", "error", err)
+		select {
+		case c.errCh <- fmt.Errorf("파일시스템 동기화 실패: %w", err):
+		default:
+		}
+	}
+}
+
+// BatchSyncError returns the error of the final batch sync, if any
+func (c *Copier) BatchSyncError() error {
+	c.progressMux.Lock()
+	defer c.progressMux.Unlock()
+	return c.batchSyncErr
+}
+
 // SetTotal sets the total files and size for progress calculation
 func (c *Copier) SetTotal(totalFiles, totalSize int64) {
 	c.progressMux.Lock()
@@ -436,7 +965,86 @@ func (c *Copier) Errors() <-chan error {
 }
 
 // Cancel stops ongoing copy as soon as possible
-func (c *Copier) Cancel() { atomic.StoreInt32(&c.canceled, 1) }
+func (c *Copier) Cancel() {
+	c.log.Info("복사 취소 요청")
+	atomic.StoreInt32(&c.canceled, 1)
+	// 일시정지로 대기 중인 워커도 깨워서 종료시킴
+	c.pauseMu.Lock()
+	c.pauseCond.Broadcast()
+	c.pauseMu.Unlock()
+}
+
+// Stop ends the run gracefully: files already being copied are finished,
+// files still queued are dropped. A paused copy is resumed so in-flight
+// files can complete.
+func (c *Copier) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopping, 0, 1) {
+		return
+	}
+	c.log.Info("복사 중단 요청 (진행 중인 파일은 마무리)")
+	c.Resume()
+	// 대기 중인 워커를 깨워 남은 작업을 버리게 함
+	c.pauseMu.Lock()
+	c.pauseCond.Broadcast()
+	c.pauseMu.Unlock()
+}
+
+// IsCanceled reports whether Cancel or Stop was called
+func (c *Copier) IsCanceled() bool {
+	return atomic.LoadInt32(&c.canceled) == 1 || atomic.LoadInt32(&c.stopping) == 1
+}
+
+// Pause parks all workers at the next safe point (between buffer reads)
+func (c *Copier) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if atomic.LoadInt32(&c.paused) == 1 {
+		return
+	}
+	c.pausedAt = time.Now()
+	atomic.StoreInt32(&c.paused, 1)
+	c.log.Info("일시정지")
+}
+
+// Resume releases workers parked by Pause
+func (c *Copier) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if atomic.LoadInt32(&c.paused) == 0 {
+		return
+	}
+	paused := time.Since(c.pausedAt)
+	c.pausedTotal += paused
+	atomic.StoreInt32(&c.paused, 0)
+	c.log.Info("재개", "paused", paused.Round(time.Millisecond))
+	c.pauseCond.Broadcast()
+}
+
+// IsPaused reports whether the copy is currently paused
+func (c *Copier) IsPaused() bool { return atomic.LoadInt32(&c.paused) == 1 }
+
+// waitIfPaused blocks while paused; returns false when the copy was canceled
+func (c *Copier) waitIfPaused() bool {
+	if atomic.LoadInt32(&c.paused) == 1 {
+		c.pauseMu.Lock()
+		for atomic.LoadInt32(&c.paused) == 1 && atomic.LoadInt32(&c.canceled) == 0 {
+			c.pauseCond.Wait()
+		}
+		c.pauseMu.Unlock()
+	}
+	return atomic.LoadInt32(&c.canceled) == 0
+}
+
+// elapsed returns time since start excluding paused intervals
+func (c *Copier) elapsed() time.Duration {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	d := time.Since(c.startTime) - c.pausedTotal
+	if atomic.LoadInt32(&c.paused) == 1 {
+		d -= time.Since(c.pausedAt)
+	}
+	return d
+}
 
 // SetWorkerCount tunes parallelism (call before CopyFilesParallel)
 func (c *Copier) SetWorkerCount(n int) {
@@ -446,6 +1054,10 @@ func (c *Copier) SetWorkerCount(n int) {
 	c.workerCount = n
 }
 
+// SetFilter applies the scanner's include/exclude rules to the directories
+// created up front, so excluded trees are not recreated on the target
+func (c *Copier) SetFilter(f scanner.Filter) { c.filter = f }
+
 // SetBufferSizeMB sets per-worker buffer size (MB)
 func (c *Copier) SetBufferSizeMB(mb int) {
 	if mb <= 0 {
@@ -0,0 +1,199 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"superfast-copy-util/scanner"
+)
+
+// writeTree creates the files (relative slash paths to content) under root
+// and returns their absolute paths in sorted order
+func writeTree(t *testing.T, root string, files map[string]string) []string {
+	t.Helper()
+	var paths []string
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// runCopy copies files with c and returns every result once the run is over
+func runCopy(t *testing.T, c *Copier, files []string) []CopyResult {
+	t.Helper()
+	c.CopyFilesParallel(files)
+	return drain(c)
+}
+
+// drain consumes the copier channels until they close
+func drain(c *Copier) []CopyResult {
+	go func() {
+		for range c.Progress() {
+		}
+	}()
+	go func() {
+		for range c.Errors() {
+		}
+	}()
+	var results []CopyResult
+	for r := range c.Results() {
+		results = append(results, r)
+	}
+	return results
+}
+
+// readFile returns the content of root/rel ("" when it cannot be read)
+func readFile(root, rel string) string {
+	b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// exists reports whether root/rel exists
+func exists(root, rel string) bool {
+	_, err := os.Lstat(filepath.Join(root, filepath.FromSlash(rel)))
+	return err == nil
+}
+
+// Paused workers hold every file at the next buffer boundary until Resume
+func TestPauseResume(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	files := writeTree(t, src, map[string]string{"a.txt": "aaa", "b/b.txt": "bbb", "c.txt": "ccc"})
+
+	c := NewCopier(src, dst, false)
+	c.Pause()
+	c.CopyFilesParallel(files)
+	results := make(chan []CopyResult, 1)
+	go func() { results <- drain(c) }()
+
+	time.Sleep(200 * time.Millisecond)
+	if !c.IsPaused() {
+		t.Fatal("not paused")
+	}
+	for _, rel := range []string{"a.txt", "b/b.txt", "c.txt"} {
+		if readFile(dst, rel) != "" {
+			t.Errorf("%s was written while paused", rel)
+		}
+	}
+	select {
+	case <-results:
+		t.Fatal("run finished while paused")
+	default:
+	}
+
+	c.Resume()
+	select {
+	case got := <-results:
+		if len(got) != len(files) {
+			t.Fatalf("got %d results, want %d", len(got), len(files))
+		}
+		for _, r := range got {
+			if !r.Success {
+				t.Errorf("%s: %v", r.FilePath, r.Error)
+			}
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("run did not finish after Resume")
+	}
+	if readFile(dst, "b/b.txt") != "bbb" {
+		t.Error("b/b.txt not copied after Resume")
+	}
+}
+
+// Stop releases paused workers and ends the run without copying the rest
+func TestStopWhilePaused(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	files := writeTree(t, src, map[string]string{"a.txt": "a", "b.txt": "b"})
+
+	c := NewCopier(src, filepath.Join(base, "dst"), false)
+	c.Pause()
+	c.CopyFilesParallel(files)
+	done := make(chan struct{})
+	go func() {
+		drain(c)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	c.Stop()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not end a paused run")
+	}
+	if !c.IsCanceled() || c.IsPaused() {
+		t.Errorf("canceled=%v paused=%v after Stop", c.IsCanceled(), c.IsPaused())
+	}
+}
+
+// Streaming copies files as they arrive; totals grow with the feed and are
+// final once it closes
+func TestCopyFromScanner(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	files := writeTree(t, src, map[string]string{"a.txt": "a", "d/b.txt": "bb", "d/e/c.txt": "ccc"})
+	if err := os.MkdirAll(filepath.Join(src, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	feed := make(chan scanner.FileInfo)
+	c := NewCopier(src, dst, false)
+	c.CopyFromScanner(feed)
+	var last CopyProgress
+	progressDone := make(chan struct{})
+	go func() {
+		for p := range c.Progress() {
+			last = p
+		}
+		close(progressDone)
+	}()
+	go func() {
+		for range c.Errors() {
+		}
+	}()
+
+	// 첫 파일이 복사된 뒤에 나머지를 보냄: 목록이 끝나기 전에 복사가 시작됨
+	feed <- scanner.FileInfo{Path: files[0], Size: 1}
+	first := <-c.Results()
+	if !first.Success || readFile(dst, "a.txt") != "a" {
+		t.Fatalf("first file not copied before the feed ended: %+v", first)
+	}
+	for _, f := range files[1:] {
+		info, _ := os.Stat(f)
+		feed <- scanner.FileInfo{Path: f, Size: info.Size()}
+	}
+	close(feed)
+	n := 1
+	for r := range c.Results() {
+		if !r.Success {
+			t.Errorf("%s: %v", r.FilePath, r.Error)
+		}
+		n++
+	}
+	<-progressDone
+	if n != len(files) {
+		t.Errorf("got %d results, want %d", n, len(files))
+	}
+	if !last.TotalsFinal || last.TotalFiles != int64(len(files)) || last.TotalSize != 6 {
+		t.Errorf("final totals %d files / %d B (final=%v), want %d / 6", last.TotalFiles, last.TotalSize, last.TotalsFinal, len(files))
+	}
+	if readFile(dst, "d/e/c.txt") != "ccc" || !exists(dst, "empty") {
+		t.Error("tree not copied")
+	}
+}
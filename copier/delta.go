@@ -0,0 +1,351 @@
+package copier
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+const (
+	minDeltaBlock = 8 * 1024
+	maxDeltaBlock = 1024 * 1024
+)
+
+// SetDeltaThreshold enables rsync-style delta transfer for files of at least
+// bytes whose target already exists: only blocks that differ from the old
+// target are written. 0 disables it (call before CopyFilesParallel)
+func (c *Copier) SetDeltaThreshold(bytes int64) {
+	if bytes < 0 {
+		bytes = 0
+	}
+	c.deltaThreshold = bytes
+}
+
+// deltaOp is one step of rebuilding the source from the old target: either a
+// literal range read from the source or a range reused from the old target
+type deltaOp struct {
+	literal bool
+	srcOff  int64 // 새 파일(소스)에서의 위치
+	tgtOff  int64 // 재사용 구간의 기존 대상 위치
+	length  int64
+}
+
+// blockSignatures holds weak (rolling) and strong checksums of the old target
+type blockSignatures struct {
+	blockSize int
+	weak      map[uint32][]int
+	strong    [][sha256.Size]byte
+}
+
+// deltaBlockSize picks roughly sqrt(size) like rsync, clamped to sane bounds
+func deltaBlockSize(size int64) int {
+	bs := int(math.Sqrt(float64(size)))
+	if bs < minDeltaBlock {
+		return minDeltaBlock
+	}
+	if bs > maxDeltaBlock {
+		return maxDeltaBlock
+	}
+	// 1KB 단위로 맞춤
+	return bs &^ 1023
+}
+
+// weakSum is the rsync rolling checksum of a block split into its two halves
+func weakSum(block []byte) (a, b uint32) {
+	n := uint32(len(block))
+	for i, x := range block {
+		a += uint32(x)
+		b += (n - uint32(i)) * uint32(x)
+	}
+	return a & 0xffff, b & 0xffff
+}
+
+// useDelta reports whether a file should go through delta transfer
+func (c *Copier) useDelta(targets []TargetResult, srcInfo os.FileInfo) bool {
+	if c.deltaThreshold <= 0 || srcInfo.Size() < c.deltaThreshold {
+		return false
+	}
+	// 여러 대상 동시 기록, 백업(기존 파일이 이미 옮겨짐)과는 함께 쓰지 않음
+	if len(targets) != 1 || pendingTargets(targets) != 1 || c.backup.Mode != BackupNone {
+		return false
+	}
+	info, err := os.Lstat(normalizeLongPath(targets[0].Path))
+	return err == nil && info.Mode().IsRegular() && info.Size() >= minDeltaBlock
+}
+
+// copyFileDelta rebuilds the target from its old version and the changed source
+// ranges. When every reused block stays at its offset the target is patched in
+// place; otherwise (or when the target is hard-linked elsewhere, e.g. into a
+// snapshot) a temp file is assembled and renamed over it. Returns the number of
+// bytes reused from the old target.
+func (c *Copier) copyFileDelta(srcPath string, t *TargetResult, buffer []byte) (int64, error) {
+	dstPath := normalizeLongPath(t.Path)
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return 0, fmt.Errorf("소스 파일 열기 실패: %w", err)
+	}
+	defer src.Close()
+	srcInfo, err := src.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("파일 정보 읽기 실패: %w", err)
+	}
+	old, err := os.Open(dstPath)
+	if err != nil {
+		return 0, fmt.Errorf("기존 대상 열기 실패: %w", err)
+	}
+	oldInfo, err := old.Stat()
+	if err != nil {
+		old.Close()
+		return 0, fmt.Errorf("기존 대상 정보 읽기 실패: %w", err)
+	}
+
+	sigs, err := c.signatures(old, oldInfo.Size())
+	if err != nil {
+		old.Close()
+		return 0, err
+	}
+	ops, err := c.computeDelta(src, sigs)
+	if err != nil {
+		old.Close()
+		return 0, err
+	}
+
+	var reused int64
+	inPlace := singleLink(oldInfo)
+	for _, op := range ops {
+		if op.literal {
+			continue
+		}
+		reused += op.length
+		if op.tgtOff != op.srcOff {
+			inPlace = false
+		}
+	}
+
+	if inPlace {
+		old.Close()
+		return reused, c.patchInPlace(src, dstPath, t.Root, ops, srcInfo.Size(), buffer)
+	}
+	return reused, c.assembleDelta(src, old, dstPath, t.Root, ops, buffer)
+}
+
+// signatures reads the old target block by block
+func (c *Copier) signatures(old *os.File, size int64) (*blockSignatures, error) {
+	bs := deltaBlockSize(size)
+	sigs := &blockSignatures{blockSize: bs, weak: make(map[uint32][]int)}
+	block := make([]byte, bs)
+	for idx := 0; ; idx++ {
+		if !c.waitIfPaused() {
+			return nil, ErrCanceled
+		}
+		n, err := io.ReadFull(old, block)
+		if n < bs {
+			// 마지막 불완전 블록은 재사용 대상에서 제외 (소스 쪽은 리터럴로 처리)
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, fmt.Errorf("기존 대상 읽기 실패: %w", err)
+		}
+		a, b := weakSum(block)
+		w := a | b<<16
+		sigs.weak[w] = append(sigs.weak[w], idx)
+		sigs.strong = append(sigs.strong, sha256.Sum256(block))
+	}
+	return sigs, nil
+}
+
+// computeDelta rolls the weak checksum over the source one byte at a time and
+// emits a reuse op whenever a block of the old target matches
+func (c *Copier) computeDelta(src io.Reader, sigs *blockSignatures) ([]deltaOp, error) {
+	bs := sigs.blockSize
+	var ops []deltaOp
+	emit := func(op deltaOp) {
+		if n := len(ops); n > 0 {
+			last := &ops[n-1]
+			// 연속된 같은 종류 구간은 하나로 합침
+			if last.literal == op.literal && last.srcOff+last.length == op.srcOff &&
+				(op.literal || last.tgtOff+last.length == op.tgtOff) {
+				last.length += op.length
+				return
+			}
+		}
+		ops = append(ops, op)
+	}
+
+	window := make([]byte, 0, 4*bs+maxDeltaBlock)
+	var base int64 // window[0] 의 소스 위치
+	eof := false
+	// ensure makes window[i:i+need] available when the source has that much left
+	ensure := func(i, need int) (int, error) {
+		for len(window)-i < need && !eof {
+			if i > 0 && len(window) == cap(window) {
+				n := copy(window, window[i:])
+				window = window[:n]
+				base += int64(i)
+				i = 0
+			}
+			n, err := src.Read(window[len(window):cap(window)])
+			window = window[:len(window)+n]
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return i, fmt.Errorf("읽기 실패: %w", err)
+			}
+		}
+		return i, nil
+	}
+
+	var litStart int64
+	var a, b uint32
+	rolled := false
+	i := 0
+	for steps := 0; ; steps++ {
+		if steps%(1<<20) == 0 && !c.waitIfPaused() {
+			return nil, ErrCanceled
+		}
+		var err error
+		if i, err = ensure(i, bs+1); err != nil {
+			return nil, err
+		}
+		if len(window)-i < bs {
+			break
+		}
+		pos := base + int64(i)
+		if !rolled {
+			a, b = weakSum(window[i : i+bs])
+			rolled = true
+		}
+		if idxs, ok := sigs.weak[a|b<<16]; ok {
+			strong := sha256.Sum256(window[i : i+bs])
+			match := -1
+			for _, idx := range idxs {
+				if sigs.strong[idx] != strong {
+					continue
+				}
+				match = idx
+				// 같은 위치의 블록을 우선 (제자리 갱신 가능)
+				if int64(idx)*int64(bs) == pos {
+					break
+				}
+			}
+			if match >= 0 {
+				if pos > litStart {
+					emit(deltaOp{literal: true, srcOff: litStart, length: pos - litStart})
+				}
+				emit(deltaOp{srcOff: pos, tgtOff: int64(match) * int64(bs), length: int64(bs)})
+				i += bs
+				litStart = pos + int64(bs)
+				rolled = false
+				continue
+			}
+		}
+		if len(window)-i == bs {
+			// 다음 바이트가 없음: 나머지는 리터럴
+			break
+		}
+		out, in := uint32(window[i]), uint32(window[i+bs])
+		a = (a - out + in) & 0xffff
+		b = (b - uint32(bs)*out + a) & 0xffff
+		i++
+	}
+	if end := base + int64(len(window)); end > litStart {
+		emit(deltaOp{literal: true, srcOff: litStart, length: end - litStart})
+	}
+	return ops, nil
+}
+
+// copyRange copies length bytes from r at off to w at dstOff
+func (c *Copier) copyRange(w io.WriterAt, r io.ReaderAt, off, dstOff, length int64, buffer []byte) error {
+	for length > 0 {
+		if !c.waitIfPaused() {
+			return ErrCanceled
+		}
+		chunk := buffer
+		if int64(len(chunk)) > length {
+			chunk = chunk[:length]
+		}
+		n, err := r.ReadAt(chunk, off)
+		if n > 0 {
+			if _, werr := w.WriteAt(chunk[:n], dstOff); werr != nil {
+				return fmt.Errorf("쓰기 실패: %w", werr)
+			}
+			atomic.AddInt64(&c.bytesCopied, int64(n))
+			off += int64(n)
+			dstOff += int64(n)
+			length -= int64(n)
+		}
+		if err != nil && !(err == io.EOF && length == 0) {
+			return fmt.Errorf("읽기 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// patchInPlace writes only the literal ranges into the existing target
+func (c *Copier) patchInPlace(src *os.File, dstPath, root string, ops []deltaOp, size int64, buffer []byte) error {
+	f, err := os.OpenFile(dstPath, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("대상 파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+	for _, op := range ops {
+		if !op.literal {
+			continue
+		}
+		if err := c.copyRange(f, src, op.srcOff, op.srcOff, op.length, buffer); err != nil {
+			return err
+		}
+	}
+	if err := f.Truncate(size); err != nil {
+		return fmt.Errorf("대상 크기 조정 실패: %w", err)
+	}
+	return c.syncTarget(f, dstPath, root)
+}
+
+// assembleDelta builds the new file next to the target and renames it over
+// (closes old)
+func (c *Copier) assembleDelta(src, old *os.File, dstPath, root string, ops []deltaOp, buffer []byte) error {
+	tmpPath := filepath.Join(filepath.Dir(dstPath), "."+filepath.Base(dstPath)+".delta~")
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		old.Close()
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	fail := func(err error) error {
+		f.Close()
+		old.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	for _, op := range ops {
+		from, off := io.ReaderAt(old), op.tgtOff
+		if op.literal {
+			from, off = src, op.srcOff
+		}
+		if err := c.copyRange(f, from, off, op.srcOff, op.length, buffer); err != nil {
+			return fail(err)
+		}
+	}
+	if c.durability == DurabilityFile || c.durability == DurabilityFileDir {
+		if err := f.Sync(); err != nil {
+			return fail(fmt.Errorf("동기화 실패: %w", err))
+		}
+	}
+	// Windows는 열린 파일을 rename 으로 교체할 수 없어 먼저 닫음
+	f.Close()
+	old.Close()
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("임시 파일 교체 실패: %w", err)
+	}
+	if c.durability == DurabilityFileDir {
+		if err := c.syncDirChain(filepath.Dir(dstPath), root); err != nil {
+			return fmt.Errorf("디렉터리 동기화 실패: %w", err)
+		}
+	}
+	return nil
+}
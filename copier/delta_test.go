@@ -0,0 +1,117 @@
+package copier
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// deltaData returns n reproducible pseudo-random bytes
+func deltaData(seed int64, n int) []byte {
+	b := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(b)
+	return b
+}
+
+func splice(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func TestCopyFileDeltaRoundTrip(t *testing.T) {
+	const size = 256 * 1024
+	old := deltaData(1, size)
+	bs := deltaBlockSize(size)
+	insert := deltaData(2, 1000) // 블록 크기의 배수가 아니어야 rolling 검사가 됨
+
+	cases := []struct {
+		name      string
+		src       []byte
+		minReused int64 // 기존 대상에서 재사용되어야 하는 최소 바이트
+		inPlace   bool  // 재사용 블록이 모두 제자리
+	}{
+		{"unchanged", old, size, true},
+		{"overwrite", splice(old[:100000], insert, old[101000:]), size - 2*int64(bs), true},
+		{"append", splice(old, insert), size, true},
+		{"truncate", old[:size-5000], size - 5000 - int64(bs), true},
+		{"insert", splice(old[:100000], insert, old[100000:]), size - 2*int64(bs), false},
+		{"delete", splice(old[:100000], old[130000:]), size - 30000 - 2*int64(bs), false},
+		{"insert delete append", splice(insert[:10], old[:50000], old[90000:200000], insert, old[200000:], insert),
+			size - 40000 - 6*int64(bs), false},
+		{"empty source", nil, 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			srcPath := filepath.Join(dir, "src.bin")
+			dstPath := filepath.Join(dir, "dst", "src.bin")
+			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(srcPath, tc.src, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(dstPath, old, 0644); err != nil {
+				t.Fatal(err)
+			}
+			before, _ := os.Stat(dstPath)
+
+			c := NewCopier(dir, filepath.Dir(dstPath), false)
+			target := TargetResult{Root: filepath.Dir(dstPath), Path: dstPath}
+			reused, err := c.copyFileDelta(srcPath, &target, make([]byte, 64*1024))
+			if err != nil {
+				t.Fatalf("copyFileDelta: %v", err)
+			}
+
+			got, err := os.ReadFile(dstPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tc.src) {
+				t.Fatalf("target differs from source (len %d, want %d)", len(got), len(tc.src))
+			}
+			if reused < tc.minReused || reused > int64(len(tc.src)) {
+				t.Errorf("reused %d bytes, want between %d and %d", reused, tc.minReused, len(tc.src))
+			}
+			after, _ := os.Stat(dstPath)
+			if same := os.SameFile(before, after); same != tc.inPlace {
+				t.Errorf("patched in place = %v, want %v", same, tc.inPlace)
+			}
+			if _, err := os.Stat(filepath.Join(filepath.Dir(dstPath), ".src.bin.delta~")); !os.IsNotExist(err) {
+				t.Errorf("temp file left behind: %v", err)
+			}
+		})
+	}
+}
+
+// A target hard-linked elsewhere (a snapshot) must not be patched in place
+func TestCopyFileDeltaKeepsLinkedTarget(t *testing.T) {
+	dir := t.TempDir()
+	old := deltaData(3, 128*1024)
+	src := splice(old[:60000], []byte("changed"), old[60007:])
+	srcPath := filepath.Join(dir, "src.bin")
+	dstPath := filepath.Join(dir, "dst.bin")
+	snapPath := filepath.Join(dir, "snapshot.bin")
+	if err := os.WriteFile(srcPath, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dstPath, old, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(dstPath, snapPath); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	c := NewCopier(dir, dir, false)
+	target := TargetResult{Root: dir, Path: dstPath}
+	if _, err := c.copyFileDelta(srcPath, &target, make([]byte, 64*1024)); err != nil {
+		t.Fatalf("copyFileDelta: %v", err)
+	}
+	if got, _ := os.ReadFile(dstPath); !bytes.Equal(got, src) {
+		t.Error("target differs from source")
+	}
+	if got, _ := os.ReadFile(snapPath); !bytes.Equal(got, old) {
+		t.Error("snapshot copy was modified")
+	}
+}
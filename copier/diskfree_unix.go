@@ -0,0 +1,29 @@
+//go:build !windows
+
+package copier
+
+import (
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskFree returns free bytes and inodes available to unprivileged users
+func diskFree(dir string) (bytes, inodes uint64, inodesKnown bool, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, 0, false, err
+	}
+	// 일부 파일시스템(btrfs 등)은 inode 총량을 0으로 보고함
+	return uint64(st.Bavail) * uint64(st.Bsize), uint64(st.Ffree), st.Files > 0, nil
+}
+
+// fsKey identifies the filesystem holding dir (its device number); roots
+// with the same key draw on the same free space
+func fsKey(dir string) string {
+	var st unix.Stat_t
+	if err := unix.Stat(dir, &st); err != nil {
+		return dir
+	}
+	return strconv.FormatUint(uint64(st.Dev), 10)
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package copier
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// diskFree returns free bytes for the caller; NTFS has no inode limit to report
+func diskFree(dir string) (bytes, inodes uint64, inodesKnown bool, err error) {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	var avail, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(p, &avail, &total, &totalFree); err != nil {
+		return 0, 0, false, err
+	}
+	return avail, 0, false, nil
+}
+
+// fsKey identifies the volume holding dir (its mount point); roots with the
+// same key draw on the same free space
+func fsKey(dir string) string {
+	p, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return dir
+	}
+	buf := make([]uint16, windows.MAX_PATH+1)
+	if err := windows.GetVolumePathName(p, &buf[0], uint32(len(buf))); err != nil {
+		return dir
+	}
+	return strings.ToLower(windows.UTF16ToString(buf))
+}
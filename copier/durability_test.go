@@ -0,0 +1,53 @@
+package copier
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDurabilityLevels(t *testing.T) {
+	for _, tt := range []struct {
+		level  Durability
+		synced bool
+	}{
+		{DurabilityNone, false},
+		{DurabilityFile, true},
+		{DurabilityFileDir, true},
+		{DurabilityBatch, false}, // 파일별로는 동기화하지 않고 마지막에 한 번
+	} {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "src")
+			dst := filepath.Join(base, "dst")
+			files := writeTree(t, src, map[string]string{"a.txt": "a", "x/y/b.txt": "b"})
+
+			c := NewCopier(src, dst, false)
+			c.SetDurability(tt.level)
+			for _, r := range runCopy(t, c, files) {
+				if !r.Success || r.Synced != tt.synced {
+					t.Errorf("%s: success=%v synced=%v, want synced=%v (%v)", r.FilePath, r.Success, r.Synced, tt.synced, r.Error)
+				}
+			}
+			if err := c.BatchSyncError(); err != nil {
+				t.Errorf("batch sync: %v", err)
+			}
+			// dir 수준: 새로 만든 상위 디렉터리도 루트까지 동기화됨
+			_, dirSynced := c.syncedDirs.Load(filepath.Join(dst, "x"))
+			if want := tt.level == DurabilityFileDir; dirSynced != want {
+				t.Errorf("created directory synced = %v, want %v", dirSynced, want)
+			}
+		})
+	}
+}
+
+func TestParseDurability(t *testing.T) {
+	for in, want := range map[string]Durability{"": DurabilityNone, "none": DurabilityNone, "FILE": DurabilityFile,
+		"dir": DurabilityFileDir, "file+dir": DurabilityFileDir, " batch": DurabilityBatch} {
+		if got, err := ParseDurability(in); err != nil || got != want {
+			t.Errorf("ParseDurability(%q) = %v, %v", in, got, err)
+		}
+	}
+	if _, err := ParseDurability("always"); err == nil {
+		t.Error("unknown level accepted")
+	}
+}
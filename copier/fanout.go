@@ -0,0 +1,91 @@
+package copier
+
+import (
+	"fmt"
+)
+
+// TargetResult is the outcome of a copy for one destination root
+type TargetResult struct {
+	Root       string
+	Path       string
+	Success    bool
+	Error      error
+	BackupPath string // 덮어쓰기 전에 옮겨 둔 기존 파일 위치
+	Linked     bool   // 이전 스냅숏의 파일에 하드 링크됨 (복사 없음)
+	Unchanged  bool   // 이미 같은 파일이라 건드리지 않음
+	Renamed    string // 대상 파일시스템 규칙으로 이름이 바뀐 이유 (names.go)
+	Collision  string // 대상 이름이 겹친 다른 소스 파일 (collide.go)
+}
+
+// AddTarget adds another destination root; each buffer read from the source is
+// written to every root, and a failing root does not abort the others
+// (call before CopyFilesParallel)
+func (c *Copier) AddTarget(dir string) {
+	if dir == "" {
+		return
+	}
+	c.extraTargets = append(c.extraTargets, dir)
+}
+
+// TargetDirs returns all destination roots, primary first
+func (c *Copier) TargetDirs() []string {
+	return append([]string{c.targetDir}, c.extraTargets...)
+}
+
+// newTargetResults prepares one result slot per destination root; rename
+// moves an entry left in another normalisation form to the wanted name
+func (c *Copier) newTargetResults(relPath string, rename bool) []TargetResult {
+	roots := c.TargetDirs()
+	targets := make([]TargetResult, len(roots))
+	for i, root := range roots {
+		rel, renamed := c.targetRel(root, relPath)
+		targets[i] = TargetResult{Root: root, Path: c.matchForms(root, rel, rename), Renamed: renamed}
+	}
+	return targets
+}
+
+// liveTargets counts targets that have not failed yet
+func liveTargets(targets []TargetResult) int {
+	n := 0
+	for _, t := range targets {
+		if t.Error == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// pendingTargets counts live targets that still need their content written
+func pendingTargets(targets []TargetResult) int {
+	n := 0
+	for _, t := range targets {
+		if t.Error == nil && !t.Success {
+			n++
+		}
+	}
+	return n
+}
+
+// failTargets marks every target still being written as failed with err
+func failTargets(targets []TargetResult, err error) {
+	for i := range targets {
+		if targets[i].Error == nil && !targets[i].Success {
+			targets[i].Error = err
+			targets[i].Success = false
+		}
+	}
+}
+
+// firstTargetError returns the first failure, prefixed with its root when
+// there is more than one destination
+func firstTargetError(targets []TargetResult) error {
+	for _, t := range targets {
+		if t.Error != nil {
+			if len(targets) > 1 {
+				return fmt.Errorf("%s: %w", t.Root, t.Error)
+			}
+			return t.Error
+		}
+	}
+	return nil
+}
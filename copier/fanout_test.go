@@ -0,0 +1,59 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFanOutCopiesEveryRoot(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	roots := []string{filepath.Join(base, "d1"), filepath.Join(base, "d2"), filepath.Join(base, "d3")}
+	files := writeTree(t, src, map[string]string{"a.txt": "a", "sub/b.bin": string(deltaData(7, 300*1024))})
+
+	c := NewCopier(src, roots[0], false)
+	c.AddTarget(roots[1])
+	c.AddTarget(roots[2])
+	c.AddTarget("") // 빈 값은 무시
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success || len(r.Targets) != len(roots) {
+			t.Errorf("%s: success=%v targets=%d (%v)", r.FilePath, r.Success, len(r.Targets), r.Error)
+		}
+	}
+	for _, root := range roots {
+		if readFile(root, "a.txt") != "a" || readFile(root, "sub/b.bin") != readFile(src, "sub/b.bin") {
+			t.Errorf("%s differs from the source", root)
+		}
+	}
+}
+
+// A root that cannot be written fails on its own; the others still get the copy
+func TestFanOutFailingRoot(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	good := filepath.Join(base, "good")
+	bad := filepath.Join(base, "bad")
+	files := writeTree(t, src, map[string]string{"sub/a.txt": "a"})
+	// 디렉터리 자리에 파일이 있어 bad 아래에는 만들 수 없음
+	if err := os.WriteFile(bad, []byte("not a dir"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCopier(src, bad, false)
+	c.AddTarget(good)
+	results := runCopy(t, c, files)
+	if len(results) != 1 {
+		t.Fatalf("got %d results", len(results))
+	}
+	r := results[0]
+	if r.Success || r.Error == nil {
+		t.Error("a failed root should fail the file")
+	}
+	if r.Targets[0].Error == nil || !r.Targets[1].Success {
+		t.Errorf("per-root results: bad=%v good=%v", r.Targets[0].Error, r.Targets[1].Success)
+	}
+	if readFile(good, "sub/a.txt") != "a" {
+		t.Error("healthy root was not written")
+	}
+}
@@ -0,0 +1,132 @@
+package copier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SetLinkDest enables snapshot mode (like rsync --link-dest): files whose size
+// and modification time match the copy in the previous snapshot directory are
+// hard-linked from there instead of copied. A relative dir is resolved against
+// each target root. Implies SetPreserveTimes(true) so the next snapshot can
+// compare against this one (call before CopyFilesParallel)
+func (c *Copier) SetLinkDest(dir string) {
+	c.linkDest = dir
+	if dir != "" {
+		c.preserveTimes = true
+	}
+}
+
+// LinkDest returns the previous snapshot directory ("" when disabled)
+func (c *Copier) LinkDest() string { return c.linkDest }
+
+// SetPreserveTimes copies the source modification time onto each target
+func (c *Copier) SetPreserveTimes(preserve bool) { c.preserveTimes = preserve }
+
+// linkDestPath returns the previous snapshot's copy of relPath for a target root
+func (c *Copier) linkDestPath(root, relPath string) string {
+	dir := c.linkDest
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	rel, _ := c.targetRel(root, relPath)
+	return c.matchForms(dir, rel, false)
+}
+
+// unchangedInSnapshot reports whether the previous snapshot holds an identical
+// copy of the source (same size, same mtime to the second)
+func (c *Copier) unchangedInSnapshot(srcInfo os.FileInfo, root, relPath string) (string, bool) {
+	if c.linkDest == "" {
+		return "", false
+	}
+	prev := c.linkDestPath(root, relPath)
+	info, err := os.Lstat(normalizeLongPath(prev))
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+	// FAT/SMB 등은 초 단위 이하 정밀도가 없어 초 단위로 비교
+	if info.Size() != srcInfo.Size() || info.ModTime().Unix() != srcInfo.ModTime().Unix() {
+		return "", false
+	}
+	return prev, true
+}
+
+// linkUnchanged hard-links every live target whose file is unchanged since the
+// previous snapshot; targets that cannot be linked (other filesystem, link
+// limit) are left for a normal copy
+func (c *Copier) linkUnchanged(targets []TargetResult, relPath string, srcInfo os.FileInfo) {
+	for i := range targets {
+		if targets[i].Error != nil || targets[i].Success {
+			continue
+		}
+		prev, ok := c.unchangedInSnapshot(srcInfo, targets[i].Root, relPath)
+		if !ok {
+			continue
+		}
+		longDst := normalizeLongPath(targets[i].Path)
+		// 기존 대상 파일이 있으면 링크로 교체 (백업 모드에서는 이미 옮겨져 있음)
+		if info, err := os.Lstat(longDst); err == nil && info.Mode().IsRegular() {
+			if os.SameFile(info, mustLstat(prev)) {
+				targets[i].Success, targets[i].Linked = true, true
+				continue
+			}
+			if err := os.Remove(longDst); err != nil {
+				continue
+			}
+		}
+		if err := os.Link(normalizeLongPath(prev), longDst); err != nil {
+			continue
+		}
+		targets[i].Success, targets[i].Linked = true, true
+	}
+}
+
+// mustLstat returns file info or nil (os.SameFile treats nil as different)
+func mustLstat(p string) os.FileInfo {
+	info, err := os.Lstat(normalizeLongPath(p))
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// applyTimes sets the source modification time on every copied target
+func (c *Copier) applyTimes(targets []TargetResult, srcInfo os.FileInfo) {
+	if !c.preserveTimes {
+		return
+	}
+	mtime := srcInfo.ModTime()
+	for i := range targets {
+		if !targets[i].Success || targets[i].Linked || targets[i].Unchanged {
+			continue
+		}
+		if err := os.Chtimes(normalizeLongPath(targets[i].Path), mtime, mtime); err != nil {
+			targets[i].Success = false
+			targets[i].Error = fmt.Errorf("수정 시각 설정 실패: %w", err)
+		}
+	}
+}
+
+// allLinked reports whether every target was satisfied by a hard link
+func allLinked(targets []TargetResult) bool {
+	for _, t := range targets {
+		if !t.Linked {
+			return false
+		}
+	}
+	return len(targets) > 0
+}
+
+// unshareTarget removes an existing target that is hard-linked elsewhere
+// (into a -link-dest snapshot) so rewriting it cannot change the snapshot
+func unshareTarget(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || !info.Mode().IsRegular() || singleLink(info) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("하드 링크된 대상 분리 실패: %w", err)
+	}
+	return nil
+}
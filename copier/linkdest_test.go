@@ -0,0 +1,52 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Re-running a snapshot into a directory whose files are hard-linked from the
+// previous snapshot must replace the links, not write through them
+func TestLinkDestRerunKeepsPreviousSnapshot(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	snap1 := filepath.Join(base, "snap1")
+	snap2 := filepath.Join(base, "snap2")
+	files := writeTree(t, src, map[string]string{"a.txt": "old a", "b.txt": "old b"})
+
+	c := NewCopier(src, snap1, false)
+	c.SetPreserveTimes(true)
+	runCopy(t, c, files)
+
+	c = NewCopier(src, snap2, false)
+	c.SetLinkDest("../snap1")
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success || !r.Targets[0].Linked {
+			t.Fatalf("%s not linked from snap1: %+v", r.FilePath, r.Targets[0])
+		}
+	}
+
+	// 원본 변경 후 같은 snap2 로 다시 실행
+	if err := os.WriteFile(files[0], []byte("new content a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(files[0], later, later); err != nil {
+		t.Fatal(err)
+	}
+	c = NewCopier(src, snap2, false)
+	c.SetLinkDest("../snap1")
+	runCopy(t, c, files)
+
+	if got := readFile(snap2, "a.txt"); got != "new content a" {
+		t.Errorf("snap2/a.txt = %q, want the new content", got)
+	}
+	if got := readFile(snap1, "a.txt"); got != "old a" {
+		t.Errorf("snap1/a.txt = %q, previous snapshot was modified", got)
+	}
+	if got := readFile(snap1, "b.txt"); got != "old b" {
+		t.Errorf("snap1/b.txt = %q, previous snapshot was modified", got)
+	}
+}
@@ -0,0 +1,76 @@
+package copier
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// SetLogger sends run, tuning and per-file events to l; per-file events are
+// logged at debug level and failures at warn (default: discarded)
+func (c *Copier) SetLogger(l *slog.Logger) {
+	if l != nil {
+		c.log = l
+	}
+}
+
+// logRunStart records the effective settings of a run
+func (c *Copier) logRunStart() {
+	c.progressMux.Lock()
+	total := c.progress.TotalFiles
+	c.progressMux.Unlock()
+	c.log.Info("복사 시작",
+		"source", c.sourceDir,
+		"targets", c.TargetDirs(),
+		"files", total,
+		"workers", c.workerCount,
+		"bufferKB", c.currentBufferSize()/1024,
+		"schedule", c.schedule.String(),
+		"durability", c.durability.String(),
+		"conflict", c.conflict.String())
+}
+
+// logRunEnd records the totals of a finished run
+func (c *Copier) logRunEnd() {
+	c.progressMux.Lock()
+	p := c.progress
+	c.progressMux.Unlock()
+	c.log.Info("복사 종료",
+		"completed", p.CompletedFiles,
+		"failed", p.FailedFiles,
+		"skipped", p.SkippedFiles,
+		"bytes", p.CompletedSize,
+		"elapsed", time.Since(c.startTime).Round(time.Millisecond),
+		"canceled", atomic.LoadInt32(&c.canceled) == 1)
+}
+
+// logResult records one file; successful files only at debug level
+func (c *Copier) logResult(r CopyResult) {
+	if !r.Success {
+		c.log.Warn("파일 처리 실패", "path", r.FilePath, "error", r.Error, "duration", r.Duration)
+		return
+	}
+	if !c.log.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	attrs := []any{"path", r.FilePath, "size", r.Size, "duration", r.Duration}
+	switch {
+	case r.Removed:
+		attrs = append(attrs, "action", "removed")
+	case r.Skipped:
+		attrs = append(attrs, "action", "skipped")
+	case r.Linked:
+		attrs = append(attrs, "action", "linked")
+	case r.Moved:
+		attrs = append(attrs, "action", "moved")
+	case r.Delta:
+		attrs = append(attrs, "action", "delta", "reused", r.Reused)
+	default:
+		attrs = append(attrs, "action", "copied")
+	}
+	if r.Digest != "" {
+		attrs = append(attrs, "sha256", r.Digest)
+	}
+	c.log.Debug("파일 처리", attrs...)
+}
@@ -0,0 +1,224 @@
+package copier
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ConflictPolicy decides what happens when a target file already exists
+type ConflictPolicy int
+
+const (
+	ConflictOverwrite ConflictPolicy = iota // 항상 덮어쓰기 (기본)
+	ConflictUpdate                          // 크기/수정 시각이 같으면 건너뜀
+	ConflictSkip                            // 이미 있는 대상 파일은 건드리지 않음
+)
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictUpdate:
+		return "update"
+	case ConflictSkip:
+		return "skip"
+	}
+	return "overwrite"
+}
+
+// ParseConflictPolicy converts a flag value into a ConflictPolicy
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "overwrite":
+		return ConflictOverwrite, nil
+	case "update":
+		return ConflictUpdate, nil
+	case "skip":
+		return ConflictSkip, nil
+	}
+	return ConflictOverwrite, fmt.Errorf("알 수 없는 충돌 정책: %s (overwrite, update, skip)", name)
+}
+
+// SetConflictPolicy selects how existing target files are treated. Update
+// compares size and modification time (to the second) and implies
+// SetPreserveTimes(true) so later runs can compare (call before copying)
+func (c *Copier) SetConflictPolicy(p ConflictPolicy) {
+	c.conflict = p
+	if p == ConflictUpdate {
+		c.preserveTimes = true
+	}
+}
+
+// ConflictPolicy returns the policy for existing target files
+func (c *Copier) ConflictPolicy() ConflictPolicy { return c.conflict }
+
+// SetMirrorDeletes makes a queued path that no longer exists in the source
+// remove its counterpart (file or directory tree) from every target root;
+// used by watch mode to replicate deletes and renames
+func (c *Copier) SetMirrorDeletes(mirror bool) { c.mirrorDeletes = mirror }
+
+// keepExisting reports whether the policy leaves an existing target file as is
+func (c *Copier) keepExisting(srcInfo os.FileInfo, dstPath string) bool {
+	if c.conflict == ConflictOverwrite {
+		return false
+	}
+	info, err := os.Stat(normalizeLongPath(dstPath))
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if c.conflict == ConflictSkip {
+		return true
+	}
+	return info.Size() == srcInfo.Size() && info.ModTime().Unix() == srcInfo.ModTime().Unix()
+}
+
+// markUnchanged marks targets the conflict policy keeps as done
+func (c *Copier) markUnchanged(targets []TargetResult, srcInfo os.FileInfo) {
+	for i := range targets {
+		if targets[i].Error == nil && c.keepExisting(srcInfo, targets[i].Path) {
+			targets[i].Success, targets[i].Unchanged = true, true
+		}
+	}
+}
+
+// allUnchanged reports whether no target needed to be written
+func allUnchanged(targets []TargetResult) bool {
+	for _, t := range targets {
+		if !t.Unchanged {
+			return false
+		}
+	}
+	return len(targets) > 0
+}
+
+// sourceGone reports whether the source path has disappeared
+func sourceGone(longSrc string) bool {
+	_, err := os.Lstat(longSrc)
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// removeTargets deletes relPath from every target root after the source
+// was deleted or renamed away
+func (c *Copier) removeTargets(origSrc, relPath string) CopyResult {
+	// 지울 항목이므로 다른 정규화 형식이어도 이름을 바꾸지 않음
+	targets := c.newTargetResults(relPath, false)
+	if relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return CopyResult{
+			FilePath: origSrc,
+			Success:  false,
+			Error:    fmt.Errorf("대상 루트 밖의 경로는 삭제하지 않음: %s", relPath),
+		}
+	}
+	owned := c.releaseTargets(targets, relPath)
+	for i := range targets {
+		if owned[i] {
+			// 이름이 같아진 다른 소스 파일의 복사본이므로 남김
+			targets[i].Success, targets[i].Unchanged = true, true
+			continue
+		}
+		if err := os.RemoveAll(normalizeLongPath(targets[i].Path)); err != nil {
+			targets[i].Error = fmt.Errorf("대상 삭제 실패: %w", err)
+			continue
+		}
+		targets[i].Success = true
+	}
+	if err := firstTargetError(targets); err != nil {
+		return CopyResult{FilePath: origSrc, Success: false, Error: err, Targets: targets}
+	}
+	return CopyResult{FilePath: origSrc, Success: true, Removed: true, Targets: targets}
+}
+
+// backupNamePattern matches names produced by the numbered and timestamp backup modes
+var backupNamePattern = regexp.MustCompile(`(\.~[0-9]+~|\.[0-9]{8}-[0-9]{6})$`)
+
+// WalkExtraneous walks every target root and calls fn with the source path of
+// each entry that no longer exists in the source (a whole missing directory
+// is reported once). Backups, in-progress delta temp files and paths the
+// filter excludes are left out. Target entries are matched against the mapped
+// names of the source directory's entries, so escaped or normalised names are
+// not extraneous.
+func (c *Copier) WalkExtraneous(fn func(srcPath string)) error {
+	for _, root := range c.TargetDirs() {
+		skip := map[string]bool{}
+		if c.backup.Mode == BackupDir {
+			if filepath.IsAbs(c.backup.Dir) {
+				skip[filepath.Clean(c.backup.Dir)] = true
+			} else {
+				skip[filepath.Join(root, c.backup.Dir)] = true
+			}
+		}
+		if c.linkDest != "" {
+			skip[c.linkDestPath(root, "")] = true
+		}
+		if _, err := os.Stat(normalizeLongPath(root)); err != nil {
+			return fmt.Errorf("대상 폴더 검사 실패 %s: %w", root, err)
+		}
+		c.walkExtraneousDir(root, root, c.sourceDir, "", skip, fn)
+	}
+	return nil
+}
+
+// walkExtraneousDir compares one target directory with its source directory
+func (c *Copier) walkExtraneousDir(root, dstDir, srcDir, srcRel string, skip map[string]bool, fn func(string)) {
+	entries, err := os.ReadDir(normalizeLongPath(dstDir))
+	if err != nil {
+		return
+	}
+	// 소스 항목의 대상 이름 → 소스 이름
+	sources := make(map[string]string)
+	if list, err := os.ReadDir(normalizeLongPath(srcDir)); err == nil {
+		for _, e := range list {
+			rel, _ := c.targetRel(root, filepath.Join(srcRel, e.Name()))
+			sources[filepath.Base(rel)] = e.Name()
+			// 다른 정규화 형식으로 남아 있는 대상 항목도 같은 파일로 취급
+			if c.norm != NormNone {
+				sources[formKey(filepath.Base(rel))] = e.Name()
+			}
+		}
+	}
+	for _, d := range entries {
+		p := filepath.Join(dstDir, d.Name())
+		if skip[p] && d.IsDir() {
+			continue
+		}
+		if !d.IsDir() && c.isScratchName(d.Name()) {
+			continue
+		}
+		name, ok := sources[d.Name()]
+		if !ok && c.norm != NormNone {
+			name, ok = sources[formKey(d.Name())]
+		}
+		if !ok {
+			name = c.sourceRel(root, d.Name())
+		}
+		// 제외 패턴에 걸리는 항목은 동기화 대상이 아니므로 남김
+		if !c.filter.Match(filepath.Join(srcRel, name), d.IsDir()) {
+			continue
+		}
+		if ok {
+			if d.IsDir() {
+				c.walkExtraneousDir(root, p, filepath.Join(srcDir, name), filepath.Join(srcRel, name), skip, fn)
+			}
+			continue
+		}
+		fn(filepath.Join(srcDir, name))
+	}
+}
+
+// isScratchName reports whether a target file name was made by the copier
+// itself (delta temp file or a backup) rather than copied from the source
+func (c *Copier) isScratchName(name string) bool {
+	if strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".delta~") {
+		return true
+	}
+	switch c.backup.Mode {
+	case BackupSuffix:
+		return strings.HasSuffix(name, c.backup.Suffix)
+	case BackupNumbered, BackupTimestamp:
+		return backupNamePattern.MatchString(name)
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package copier
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"superfast-copy-util/scanner"
+)
+
+func TestWalkExtraneous(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	writeTree(t, src, map[string]string{"keep.txt": "k", "sub/keep.txt": "k"})
+	writeTree(t, dst, map[string]string{
+		"keep.txt": "k", "sub/keep.txt": "k",
+		"gone.txt": "g", "sub/gone.txt": "g", "olddir/a.txt": "a",
+		"x.tmp": "t", "sub/y.tmp": "t", "cache/c.txt": "c", // 제외 패턴
+		"backups/keep.txt": "b", // 절대 경로 -backup-dir
+		".keep.txt.delta~": "d", // 델타 임시 파일
+	})
+
+	c := NewCopier(src, dst, false)
+	c.SetFilter(scanner.Filter{Exclude: []string{"*.tmp", "cache/"}})
+	c.SetBackup(BackupOptions{Mode: BackupDir, Dir: filepath.Join(dst, "backups")})
+	var got []string
+	if err := c.WalkExtraneous(func(p string) {
+		rel, _ := filepath.Rel(src, p)
+		got = append(got, filepath.ToSlash(rel))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	want := []string{"gone.txt", "olddir", "sub/gone.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extraneous = %q, want %q", got, want)
+	}
+}
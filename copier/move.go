@@ -0,0 +1,161 @@
+package copier
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SetMoveMode turns the copy into a move: same-filesystem files are renamed,
+// others are copied, (optionally) verified, made durable and only then removed
+// from the source. Source directories emptied by the move are pruned at the end.
+func (c *Copier) SetMoveMode(move bool) { c.moveMode = move }
+
+// MoveMode reports whether the copier moves files
+func (c *Copier) MoveMode() bool { return c.moveMode }
+
+// SetVerify re-reads source and target after each copy and compares SHA-256
+func (c *Copier) SetVerify(verify bool) { c.verify = verify }
+
+// tryRename moves a file with a single rename when source and target share a
+// filesystem; any failure (EXDEV etc.) falls back to copy + delete
+func (c *Copier) tryRename(srcPath, dstPath string) bool {
+	return os.Rename(srcPath, dstPath) == nil
+}
+
+// confirmKept checks that targets left as they were (kept by the conflict
+// policy or hard-linked from the previous snapshot) hold the source's
+// content, so a move never deletes the only copy of new data
+func confirmKept(srcPath string, targets []TargetResult, buffer []byte) error {
+	for _, t := range targets {
+		if !t.Unchanged && !t.Linked {
+			continue
+		}
+		if _, err := verifyCopy(srcPath, normalizeLongPath(t.Path), buffer); err != nil {
+			return fmt.Errorf("소스를 지우지 않음 (기존 대상 %s 의 내용이 다름): %w", t.Path, err)
+		}
+	}
+	return nil
+}
+
+// finishMove removes the source once every copied target is durable
+func (c *Copier) finishMove(srcPath string, targets []TargetResult) error {
+	// 소스를 지우기 전에 대상이 디스크에 기록되었음을 보장
+	if c.durability != DurabilityFile && c.durability != DurabilityFileDir {
+		for _, t := range targets {
+			f, err := os.Open(normalizeLongPath(t.Path))
+			if err != nil {
+				return fmt.Errorf("대상 확인 실패: %w", err)
+			}
+			err = f.Sync()
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("동기화 실패: %w", err)
+			}
+		}
+	}
+	if err := os.Remove(srcPath); err != nil {
+		return fmt.Errorf("소스 삭제 실패 (대상에는 복사됨): %w", err)
+	}
+	if c.durability == DurabilityFileDir {
+		if err := c.syncDirChain(filepath.Dir(srcPath), c.sourceDir); err != nil {
+			return fmt.Errorf("소스 디렉터리 동기화 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// syncRenamed makes a file moved by rename durable like a copied one: the
+// content at file level, plus the new target entry and the removed source
+// entry at dir level
+func (c *Copier) syncRenamed(srcPath, dstPath, root string) error {
+	if c.durability != DurabilityFile && c.durability != DurabilityFileDir {
+		return nil
+	}
+	f, err := os.Open(dstPath)
+	if err != nil {
+		return fmt.Errorf("대상 확인 실패: %w", err)
+	}
+	err = c.syncTarget(f, dstPath, root)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if c.durability == DurabilityFileDir {
+		if err := c.syncDirChain(filepath.Dir(srcPath), c.sourceDir); err != nil {
+			return fmt.Errorf("소스 디렉터리 동기화 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// noteMoved records the source directory of a moved file for pruning
+func (c *Copier) noteMoved(srcPath string) {
+	c.movedDirs.Store(filepath.Dir(srcPath), true)
+}
+
+// ErrVerifyMismatch reports a target whose content differs from the source
+var ErrVerifyMismatch = errors.New("검증 실패: 소스와 대상 내용이 다름")
+
+// verifyCopy compares size and SHA-256 of source and target and returns
+// the source digest
+func verifyCopy(srcPath, dstPath string, buffer []byte) ([]byte, error) {
+	srcSum, srcSize, err := fileDigest(srcPath, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("검증용 소스 읽기 실패: %w", err)
+	}
+	dstSum, dstSize, err := fileDigest(dstPath, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("검증용 대상 읽기 실패: %w", err)
+	}
+	if srcSize != dstSize || !bytes.Equal(srcSum, dstSum) {
+		return nil, ErrVerifyMismatch
+	}
+	return srcSum, nil
+}
+
+// fileDigest returns the SHA-256 and size of a file
+func fileDigest(path string, buffer []byte) ([]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.CopyBuffer(h, f, buffer)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.Sum(nil), n, nil
+}
+
+// pruneSourceDirs removes the source directories files were moved out of,
+// and ancestors that became empty with them (deepest first); directories
+// this run did not touch and the source root itself are kept
+func (c *Copier) pruneSourceDirs() {
+	root := filepath.Clean(c.sourceDir)
+	seen := make(map[string]bool)
+	var dirs []string
+	c.movedDirs.Range(func(key, _ any) bool {
+		for d := key.(string); d != root && strings.HasPrefix(d, root+string(filepath.Separator)); d = filepath.Dir(d) {
+			if seen[d] {
+				break
+			}
+			seen[d] = true
+			dirs = append(dirs, d)
+		}
+		return true
+	})
+	// 깊은 경로부터 삭제해야 상위 디렉터리가 비게 됨
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+	for _, d := range dirs {
+		// 비어 있지 않으면 실패하므로 남은 파일은 그대로 보존됨
+		_ = os.Remove(normalizeLongPath(d))
+	}
+}
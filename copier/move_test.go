@@ -0,0 +1,107 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMovePrunesOnlyMovedDirs(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	writeTree(t, src, map[string]string{"a/f.txt": "f", "d/e/g.txt": "g", "c/other.txt": "o"})
+	if err := os.MkdirAll(filepath.Join(src, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{filepath.Join(src, "a", "f.txt"), filepath.Join(src, "d", "e", "g.txt")}
+
+	c := NewCopier(src, dst, false)
+	c.SetMoveMode(true)
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success || !r.Moved {
+			t.Fatalf("%s: success=%v moved=%v err=%v", r.FilePath, r.Success, r.Moved, r.Error)
+		}
+	}
+
+	for _, rel := range []string{"a", "d/e", "d"} {
+		if exists(src, rel) {
+			t.Errorf("%s was not pruned", rel)
+		}
+	}
+	// 이번 실행이 옮기지 않은 디렉터리는 비어 있어도 남김
+	for _, rel := range []string{"empty", "c/other.txt"} {
+		if !exists(src, rel) {
+			t.Errorf("%s was removed", rel)
+		}
+	}
+	if readFile(dst, "a/f.txt") != "f" || readFile(dst, "d/e/g.txt") != "g" {
+		t.Error("moved files missing from the target")
+	}
+}
+
+// A move done by rename reports Synced only when the durability level made
+// it durable
+func TestMoveRenameDurability(t *testing.T) {
+	for _, tt := range []struct {
+		durability Durability
+		synced     bool
+	}{
+		{DurabilityNone, false},
+		{DurabilityFile, true},
+		{DurabilityFileDir, true},
+	} {
+		t.Run(tt.durability.String(), func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "src")
+			dst := filepath.Join(base, "dst")
+			files := writeTree(t, src, map[string]string{"sub/f.txt": "f"})
+
+			c := NewCopier(src, dst, false)
+			c.SetMoveMode(true)
+			c.SetDurability(tt.durability)
+			results := runCopy(t, c, files)
+			if len(results) != 1 || !results[0].Success || !results[0].Moved {
+				t.Fatalf("results = %+v", results)
+			}
+			if results[0].Synced != tt.synced {
+				t.Errorf("Synced = %v, want %v", results[0].Synced, tt.synced)
+			}
+			if exists(src, "sub/f.txt") || readFile(dst, "sub/f.txt") != "f" {
+				t.Error("file was not moved")
+			}
+		})
+	}
+}
+
+// A target the conflict policy keeps must match the source before a move may
+// delete the source; otherwise the source is the only copy of its content
+func TestMoveKeepsSourceWhenKeptTargetDiffers(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	files := writeTree(t, src, map[string]string{"same.txt": "same", "diff.txt": "new"})
+	writeTree(t, dst, map[string]string{"same.txt": "same", "diff.txt": "old"})
+
+	c := NewCopier(src, dst, false)
+	c.SetMoveMode(true)
+	c.SetConflictPolicy(ConflictSkip)
+	for _, r := range runCopy(t, c, files) {
+		switch filepath.Base(r.FilePath) {
+		case "same.txt":
+			if !r.Success || !r.Moved {
+				t.Errorf("same.txt: success=%v moved=%v err=%v", r.Success, r.Moved, r.Error)
+			}
+		case "diff.txt":
+			if r.Success || r.Moved {
+				t.Errorf("diff.txt: success=%v moved=%v, want a failure", r.Success, r.Moved)
+			}
+		}
+	}
+	if exists(src, "same.txt") {
+		t.Error("source of an identical kept target was not removed")
+	}
+	if readFile(src, "diff.txt") != "new" || readFile(dst, "diff.txt") != "old" {
+		t.Error("differing file was lost or overwritten")
+	}
+}
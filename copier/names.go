@@ -0,0 +1,266 @@
+package copier
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// TargetFS selects the file name rules applied to target paths
+type TargetFS int
+
+const (
+	TargetFSAuto     TargetFS = iota // 대상 파일시스템을 감지해 결정 (FAT/exFAT/NTFS 이면 windows)
+	TargetFSPosix                    // 이름을 그대로 사용
+	TargetFSWindows                  // 금지 문자, 끝의 점/공백, 예약 이름(CON 등)을 이스케이프
+	TargetFSUnescape                 // windows 규칙으로 이스케이프된 이름을 원래대로 되돌림
+)
+
+func (t TargetFS) String() string {
+	switch t {
+	case TargetFSPosix:
+		return "posix"
+	case TargetFSWindows:
+		return "windows"
+	case TargetFSUnescape:
+		return "unescape"
+	}
+	return "auto"
+}
+
+// ParseTargetFS converts a flag value into a TargetFS
+func ParseTargetFS(name string) (TargetFS, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return TargetFSAuto, nil
+	case "posix", "none":
+		return TargetFSPosix, nil
+	case "windows", "ntfs", "exfat", "fat":
+		return TargetFSWindows, nil
+	case "unescape":
+		return TargetFSUnescape, nil
+	}
+	return TargetFSAuto, fmt.Errorf("알 수 없는 대상 파일시스템: %s (auto, posix, windows, unescape)", name)
+}
+
+// SetTargetFS chooses the name rules for every target root; auto detects
+// FAT, exFAT and NTFS targets (and always applies on Windows) so names that
+// those filesystems reject are escaped instead of failing (call before copying)
+func (c *Copier) SetTargetFS(t TargetFS) { c.targetFS = t }
+
+// Name change reasons recorded in TargetResult.Renamed (normalisation adds
+// the form name, "nfc" or "nfd")
+const (
+	RenameEscape   = "escape"   // windows 규칙으로 이스케이프
+	RenameUnescape = "unescape" // 이스케이프를 되돌림
+)
+
+// rootNames are the resolved name rules of one target root
+type rootNames struct {
+	rules    TargetFS
+	foldCase bool // 대소문자를 구분하지 않는 대상
+	foldForm bool // 정규화 형식을 구분하지 않는 대상 (macOS)
+}
+
+// rootRules returns the resolved name rules of a target root
+func (c *Copier) rootRules(root string) TargetFS {
+	return c.rootNames(root).rules
+}
+
+// rootNames returns everything resolved about a target root's names
+func (c *Copier) rootNames(root string) rootNames {
+	c.namesOnce.Do(c.resolveNameRules)
+	return c.nameRules[root]
+}
+
+// resolveNameRules detects the filesystem of every target root once
+func (c *Copier) resolveNameRules() {
+	c.nameRules = make(map[string]rootNames)
+	for _, root := range c.TargetDirs() {
+		names := rootNames{rules: c.targetFS}
+		// 원격 대상은 서버 쪽 파일시스템을 알 수 없음
+		if !c.remotePush() {
+			dir := existingAncestor(root)
+			fsType, windows := detectTargetFS(dir)
+			if names.rules == TargetFSAuto && windows {
+				names.rules = TargetFSWindows
+			}
+			names.foldCase, names.foldForm = probeFolding(dir, windows)
+			c.log.Info("대상 파일시스템", "root", root, "type", fsType, "names", names.rules.String(),
+				"caseInsensitive", names.foldCase, "formInsensitive", names.foldForm)
+		}
+		if names.rules == TargetFSAuto {
+			names.rules = TargetFSPosix
+		}
+		c.nameRules[root] = names
+	}
+}
+
+// targetRel maps a source-relative path onto the names used under root and
+// returns why it changed ("" when the path is used as is; "transcode+nfc"
+// when several rules applied). Legacy names are decoded first, then escaped
+// for the target filesystem, then normalised
+func (c *Copier) targetRel(root, relPath string) (string, string) {
+	rules := c.rootRules(root)
+	if !c.namesMapped(root) || relPath == "" || relPath == "." {
+		return relPath, ""
+	}
+	parts := strings.Split(relPath, string(filepath.Separator))
+	transcoded, rulesChanged, normChanged := false, false, false
+	for i, name := range parts {
+		mapped := c.transcodeName(name)
+		transcoded = transcoded || mapped != name
+		name = mapped
+		switch rules {
+		case TargetFSWindows:
+			mapped = escapeWindowsName(name)
+		case TargetFSUnescape:
+			mapped = unescapeName(name)
+		}
+		rulesChanged = rulesChanged || mapped != name
+		normalized := c.norm.apply(mapped)
+		normChanged = normChanged || normalized != mapped
+		parts[i] = normalized
+	}
+	var reasons []string
+	if transcoded {
+		reasons = append(reasons, RenameTranscode)
+	}
+	switch {
+	case rulesChanged && rules == TargetFSUnescape:
+		reasons = append(reasons, RenameUnescape)
+	case rulesChanged:
+		reasons = append(reasons, RenameEscape)
+	}
+	if normChanged {
+		reasons = append(reasons, c.norm.String())
+	}
+	if reasons == nil {
+		return relPath, ""
+	}
+	return filepath.Join(parts...), strings.Join(reasons, "+")
+}
+
+// TargetPath returns where relPath (relative to the source root) is written
+// under root after name mapping. An existing entry in another normalisation
+// form is renamed to the wanted form, so call it only when writing
+func (c *Copier) TargetPath(root, relPath string) string {
+	rel, _ := c.targetRel(root, relPath)
+	return c.matchForms(root, rel, true)
+}
+
+// lookupPath is TargetPath without renaming: an entry in another
+// normalisation form is returned under its existing name (planning, checks)
+func (c *Copier) lookupPath(root, relPath string) string {
+	rel, _ := c.targetRel(root, relPath)
+	return c.matchForms(root, rel, false)
+}
+
+// namesMapped reports whether target names can differ from source names
+func (c *Copier) namesMapped(root string) bool {
+	return c.rootRules(root) != TargetFSPosix || c.norm != NormNone || c.nameEnc != nil
+}
+
+// sourceRel maps a path found under a target root back to the source name
+// it would have been copied from (best effort; used to find extraneous
+// target entries)
+func (c *Copier) sourceRel(root, rel string) string {
+	switch c.rootRules(root) {
+	case TargetFSWindows:
+		return unescapeName(rel)
+	case TargetFSUnescape:
+		parts := strings.Split(rel, string(filepath.Separator))
+		for i, name := range parts {
+			parts[i] = escapeWindowsName(name)
+		}
+		return filepath.Join(parts...)
+	}
+	return rel
+}
+
+// escapeBase is where escaped ASCII characters are moved: U+F000 + byte,
+// a private-use range (the same convention as Cygwin and WSL), so the
+// original name can always be restored
+const escapeBase = 0xF000
+
+// windowsIllegal lists the characters Windows filesystems reject in names
+const windowsIllegal = `"*:<>?\|`
+
+// windowsReserved are device names that cannot be used as a file name stem
+var windowsReserved = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true, "CONIN$": true, "CONOUT$": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// escapeWindowsName rewrites one path component so NTFS/exFAT/FAT accept
+// it: control and reserved characters, a trailing dot or space and the last
+// letter of a reserved device name (CON, COM1.txt, ...) become U+F0xx
+func escapeWindowsName(name string) string {
+	if name == "." || name == ".." {
+		return name
+	}
+	escape := make([]bool, len(name))
+	need := false
+	for i := 0; i < len(name); i++ {
+		if b := name[i]; b < 0x20 || strings.IndexByte(windowsIllegal, b) >= 0 {
+			escape[i], need = true, true
+		}
+	}
+	// Windows 는 끝의 점/공백을 지워 버림
+	if last := len(name) - 1; last >= 0 && (name[last] == '.' || name[last] == ' ') {
+		escape[last], need = true, true
+	}
+	// 예약 장치 이름은 확장자가 붙어도 사용할 수 없음 (CON.txt)
+	stem := name
+	if dot := strings.IndexByte(name, '.'); dot >= 0 {
+		stem = name[:dot]
+	}
+	if windowsReserved[strings.ToUpper(strings.TrimRight(stem, " "))] {
+		escape[len(stem)-1], need = true, true
+	}
+	if !need {
+		return name
+	}
+	var b strings.Builder
+	b.Grow(len(name) + 8)
+	for i := 0; i < len(name); i++ {
+		if escape[i] {
+			b.WriteRune(escapeBase + rune(name[i]))
+		} else {
+			b.WriteByte(name[i])
+		}
+	}
+	return b.String()
+}
+
+// unescapeName restores characters escaped by escapeWindowsName; bytes that
+// are not valid UTF-8 are kept as they are
+func unescapeName(name string) string {
+	if !hasEscapes(name) {
+		return name
+	}
+	var b strings.Builder
+	b.Grow(len(name))
+	for i := 0; i < len(name); {
+		r, size := utf8.DecodeRuneInString(name[i:])
+		if r >= escapeBase && r < escapeBase+0x80 {
+			b.WriteByte(byte(r - escapeBase))
+		} else {
+			b.WriteString(name[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// hasEscapes reports whether name contains an escaped character
+func hasEscapes(name string) bool {
+	for _, r := range name {
+		if r >= escapeBase && r < escapeBase+0x80 {
+			return true
+		}
+	}
+	return false
+}
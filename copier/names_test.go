@@ -0,0 +1,100 @@
+package copier
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Names Windows filesystems reject are escaped into U+F0xx and restored
+// unchanged by unescapeName
+func TestEscapeWindowsName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"plain.txt", "plain.txt"},
+		{"a:b?.txt", "a\uf03ab\uf03f.txt"},
+		{`q"<>|*\.txt`, "q\uf022\uf03c\uf03e\uf07c\uf02a\uf05c.txt"},
+		{"tab\there", "tab\uf009here"},
+		{"dots...", "dots..\uf02e"},
+		{"space ", "space\uf020"},
+		{"CON", "CO\uf04e"},
+		{"con.txt", "co\uf06e.txt"},
+		{"COM1.tar.gz", "COM\uf031.tar.gz"},
+		{"CONSOLE.txt", "CONSOLE.txt"},
+		{".", "."},
+		{"..", ".."},
+		{"한글:이름", "한글\uf03a이름"},
+	}
+	for _, tt := range tests {
+		got := escapeWindowsName(tt.name)
+		if got != tt.want {
+			t.Errorf("escapeWindowsName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if back := unescapeName(got); back != tt.name {
+			t.Errorf("unescapeName(%q) = %q, want %q", got, back, tt.name)
+		}
+		if hasEscapes(got) != (got != tt.name) {
+			t.Errorf("hasEscapes(%q) = %v", got, hasEscapes(got))
+		}
+	}
+	// 유효하지 않은 UTF-8 바이트는 그대로 둠
+	if got := unescapeName("\xb0\xa1\uf03a"); got != "\xb0\xa1:" {
+		t.Errorf("unescapeName kept %q", got)
+	}
+}
+
+// With windows rules the copy lands under the escaped name, the result
+// records why, and sourceRel maps the target name back; unescape reverses it
+func TestTargetFSRoundTrip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("소스에 금지 문자가 들어간 이름을 만들 수 없음")
+	}
+	base := t.TempDir()
+	src, mid, back := filepath.Join(base, "src"), filepath.Join(base, "mid"), filepath.Join(base, "back")
+	files := writeTree(t, src, map[string]string{"dir?/a:b.txt": "ab", "CON.log": "con", "ok.txt": "ok"})
+
+	c := NewCopier(src, mid, false)
+	c.SetTargetFS(TargetFSWindows)
+	renamed := make(map[string]string)
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success {
+			t.Fatalf("%s: %v", r.FilePath, r.Error)
+		}
+		rel, _ := filepath.Rel(src, r.FilePath)
+		renamed[filepath.ToSlash(rel)] = r.Targets[0].Renamed
+	}
+	want := map[string]string{"dir?/a:b.txt": RenameEscape, "CON.log": RenameEscape, "ok.txt": ""}
+	for rel, reason := range want {
+		if renamed[rel] != reason {
+			t.Errorf("%s: Renamed = %q, want %q", rel, renamed[rel], reason)
+		}
+	}
+	escaped := map[string]string{"dir\uf03f/a\uf03ab.txt": "dir?/a:b.txt", "CO\uf04e.log": "CON.log", "ok.txt": "ok.txt"}
+	for rel, orig := range escaped {
+		if readFile(mid, rel) != readFile(src, orig) {
+			t.Errorf("%s missing from the windows target", rel)
+		}
+		if got := c.sourceRel(mid, filepath.FromSlash(rel)); got != filepath.FromSlash(orig) {
+			t.Errorf("sourceRel(%q) = %q, want %q", rel, got, orig)
+		}
+	}
+
+	// 이스케이프된 사본을 다시 POSIX 대상으로 복사하면 원래 이름으로 돌아옴
+	var midFiles []string
+	for rel := range escaped {
+		midFiles = append(midFiles, filepath.Join(mid, filepath.FromSlash(rel)))
+	}
+	u := NewCopier(mid, back, false)
+	u.SetTargetFS(TargetFSUnescape)
+	for _, r := range runCopy(t, u, midFiles) {
+		if !r.Success {
+			t.Fatalf("%s: %v", r.FilePath, r.Error)
+		}
+	}
+	for _, orig := range escaped {
+		if readFile(back, orig) != readFile(src, orig) {
+			t.Errorf("%s not restored by unescape", orig)
+		}
+	}
+}
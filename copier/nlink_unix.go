@@ -0,0 +1,15 @@
+//go:build !windows
+
+package copier
+
+import (
+	"os"
+	"syscall"
+)
+
+// singleLink reports whether the file has no other hard links, i.e. it can be
+// modified in place without changing another snapshot
+func singleLink(info os.FileInfo) bool {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	return ok && st.Nlink == 1
+}
@@ -0,0 +1,137 @@
+package copier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NameNorm selects the Unicode normalisation form of target names
+type NameNorm int
+
+const (
+	NormNone NameNorm = iota // 소스 이름 그대로
+	NormNFC                  // 조합형 (Linux/Windows 에서 일반적)
+	NormNFD                  // 분해형 (macOS HFS+ 방식)
+)
+
+func (n NameNorm) String() string {
+	switch n {
+	case NormNFC:
+		return "nfc"
+	case NormNFD:
+		return "nfd"
+	}
+	return "none"
+}
+
+// ParseNameNorm converts a flag value into a NameNorm
+func ParseNameNorm(name string) (NameNorm, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return NormNone, nil
+	case "nfc":
+		return NormNFC, nil
+	case "nfd":
+		return NormNFD, nil
+	}
+	return NormNone, fmt.Errorf("알 수 없는 정규화 형식: %s (none, nfc, nfd)", name)
+}
+
+// SetNameNorm normalises every target name to n. Existing target entries
+// whose names differ only in normalisation form are renamed to the new form
+// (and found in the -link-dest snapshot) so incremental runs treat them as
+// the same file (call before copying)
+func (c *Copier) SetNameNorm(n NameNorm) { c.norm = n }
+
+// apply returns name in form n; invalid UTF-8 bytes are kept
+func (n NameNorm) apply(name string) string {
+	switch n {
+	case NormNFC:
+		if !norm.NFC.IsNormalString(name) {
+			return norm.NFC.String(name)
+		}
+	case NormNFD:
+		if !norm.NFD.IsNormalString(name) {
+			return norm.NFD.String(name)
+		}
+	}
+	return name
+}
+
+// formKey is the name every normalisation form of name shares
+func formKey(name string) string { return norm.NFC.String(name) }
+
+// isASCII reports whether s has no byte that normalisation could change
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchForms resolves rel under root against entries that already exist in
+// another normalisation form. With rename the old entry is moved to the
+// wanted name; without it (read-only trees such as a snapshot) the existing
+// name is used instead
+func (c *Copier) matchForms(root, rel string, rename bool) string {
+	if c.norm == NormNone || isASCII(rel) {
+		return filepath.Join(root, rel)
+	}
+	c.formMu.Lock()
+	defer c.formMu.Unlock()
+	dir := root
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		want := filepath.Join(dir, name)
+		if !isASCII(name) {
+			if old, ok := c.otherForm(dir, name); ok {
+				if !rename {
+					want = filepath.Join(dir, old)
+				} else if err := os.Rename(normalizeLongPath(filepath.Join(dir, old)), normalizeLongPath(want)); err == nil {
+					c.log.Info("정규화 형식 변경", "from", filepath.Join(dir, old), "to", want)
+					c.formDirs[dir][formKey(name)] = []string{name}
+				} else {
+					// 바꿀 수 없으면 기존 항목을 그대로 사용 (중복 생성 방지)
+					want = filepath.Join(dir, old)
+				}
+			}
+		}
+		dir = want
+	}
+	return dir
+}
+
+// otherForm returns the entry of dir that matches name in another
+// normalisation form when name itself does not exist (formMu held)
+func (c *Copier) otherForm(dir, name string) (string, bool) {
+	entries, ok := c.formDirs[dir]
+	if !ok {
+		// 디렉터리당 한 번만 읽음 (비ASCII 이름만 기록)
+		entries = make(map[string][]string)
+		list, _ := os.ReadDir(normalizeLongPath(dir))
+		for _, e := range list {
+			if n := e.Name(); !isASCII(n) {
+				entries[formKey(n)] = append(entries[formKey(n)], n)
+			}
+		}
+		if c.formDirs == nil {
+			c.formDirs = make(map[string]map[string][]string)
+		}
+		c.formDirs[dir] = entries
+	}
+	same := entries[formKey(name)]
+	for _, n := range same {
+		if n == name {
+			return "", false
+		}
+	}
+	if len(same) == 0 {
+		return "", false
+	}
+	return same[0], true
+}
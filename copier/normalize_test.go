@@ -0,0 +1,126 @@
+package copier
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestNameNormApply(t *testing.T) {
+	nfc := "한글 파일.txt"
+	nfd := norm.NFD.String(nfc)
+	tests := []struct {
+		n    NameNorm
+		in   string
+		want string
+	}{
+		{NormNFC, nfd, nfc},
+		{NormNFC, nfc, nfc},
+		{NormNFD, nfc, nfd},
+		{NormNone, nfd, nfd},
+		{NormNFC, "plain.txt", "plain.txt"},
+		{NormNFC, "\xb0\xa1.txt", "\xb0\xa1.txt"}, // 유효하지 않은 UTF-8 은 그대로
+	}
+	for _, tt := range tests {
+		if got := tt.n.apply(tt.in); got != tt.want {
+			t.Errorf("%s.apply(%q) = %q, want %q", tt.n, tt.in, got, tt.want)
+		}
+	}
+}
+
+// NFD names from a macOS source land in NFC, directories included, and the
+// result records the normalisation
+func TestNameNormCopy(t *testing.T) {
+	base := t.TempDir()
+	src, dst := filepath.Join(base, "src"), filepath.Join(base, "dst")
+	nfcRel := "사진/여름.jpg"
+	files := writeTree(t, src, map[string]string{norm.NFD.String(nfcRel): "photo", "ascii.txt": "a"})
+
+	c := NewCopier(src, dst, false)
+	c.SetNameNorm(NormNFC)
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success {
+			t.Fatalf("%s: %v", r.FilePath, r.Error)
+		}
+		want := ""
+		if filepath.Base(r.FilePath) != "ascii.txt" {
+			want = "nfc"
+		}
+		if got := r.Targets[0].Renamed; got != want {
+			t.Errorf("%s: Renamed = %q, want %q", r.FilePath, got, want)
+		}
+	}
+	if readFile(dst, nfcRel) != "photo" {
+		t.Errorf("%s not written in NFC", nfcRel)
+	}
+	if exists(dst, norm.NFD.String("사진")) {
+		t.Error("NFD directory created in the target")
+	}
+}
+
+// A target entry already in the other form is renamed, not duplicated, so
+// an incremental run sees the same file
+func TestNameNormRenamesExistingTarget(t *testing.T) {
+	base := t.TempDir()
+	src, dst := filepath.Join(base, "src"), filepath.Join(base, "dst")
+	nfc := "문서/보고서.txt"
+	files := writeTree(t, src, map[string]string{nfc: "new"})
+	writeTree(t, dst, map[string]string{norm.NFD.String(nfc): "old"})
+
+	c := NewCopier(src, dst, false)
+	c.SetNameNorm(NormNFC)
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success {
+			t.Fatalf("%s: %v", r.FilePath, r.Error)
+		}
+	}
+	if readFile(dst, nfc) != "new" {
+		t.Errorf("%s = %q, want the new content", nfc, readFile(dst, nfc))
+	}
+	entries, err := os.ReadDir(filepath.Join(dst, "문서"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("target has %d entries for one file, want 1", len(entries))
+	}
+	if top, _ := os.ReadDir(dst); len(top) != 1 {
+		t.Errorf("target root has %d entries, want 1", len(top))
+	}
+}
+
+// Two source names that only differ in form collide once normalised
+func TestNameNormCollision(t *testing.T) {
+	base := t.TempDir()
+	src, dst := filepath.Join(base, "src"), filepath.Join(base, "dst")
+	nfc := "가나.txt"
+	files := writeTree(t, src, map[string]string{nfc: "nfc", norm.NFD.String(nfc): "nfd"})
+	if len(files) != 2 {
+		t.Skip("소스 파일시스템이 정규화 형식을 구분하지 않음")
+	}
+
+	c := NewCopier(src, dst, false)
+	c.SetNameNorm(NormNFC)
+	if found := c.PlanNames(files); len(found) != 1 {
+		t.Fatalf("PlanNames found %d collisions, want 1", len(found))
+	}
+	var failed int
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success {
+			failed++
+			if !errors.Is(r.Error, ErrNameCollision) {
+				t.Errorf("%s: %v, want a name collision", r.FilePath, r.Error)
+			}
+		}
+	}
+	if failed != 1 {
+		t.Errorf("%d files failed, want 1", failed)
+	}
+	// 경로 순서로 먼저 오는 파일이 이름을 가짐
+	if got, want := readFile(dst, nfc), readFile(src, filepath.Base(files[0])); got != want {
+		t.Errorf("%s = %q, want %q", nfc, got, want)
+	}
+}
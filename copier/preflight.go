@@ -0,0 +1,192 @@
+package copier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PreflightPolicy decides what happens when the pre-flight check fails
+type PreflightPolicy int
+
+const (
+	PreflightWarn   PreflightPolicy = iota // 경고만 출력하고 계속 (기본)
+	PreflightRefuse                        // 공간 부족 시 복사 거부
+	PreflightOff                           // 점검 생략
+)
+
+// ParsePreflightPolicy converts a flag value into a PreflightPolicy
+func ParsePreflightPolicy(name string) (PreflightPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "warn":
+		return PreflightWarn, nil
+	case "refuse":
+		return PreflightRefuse, nil
+	case "off":
+		return PreflightOff, nil
+	}
+	return PreflightWarn, fmt.Errorf("알 수 없는 사전 점검 정책: %s (warn, refuse, off)", name)
+}
+
+// PreflightReport summarises what a copy needs on one target filesystem
+type PreflightReport struct {
+	Target         string // 대상 루트 (같은 파일시스템의 루트는 ", " 로 묶음)
+	Files          int64  // 복사 대상 파일 수
+	SourceBytes    int64  // 소스 총 크기
+	SkipFiles      int64  // 복사가 필요 없는 파일 수
+	OverwriteFiles int64  // 이미 대상에 있어 덮어쓸 파일 수
+	OverwriteBytes int64  // 덮어쓰면서 회수되는 기존 대상 크기
+	NeededBytes    int64  // 순 필요 공간
+	NeededInodes   int64  // 새로 만들어질 파일 수
+	FreeBytes      uint64 // 대상 파일시스템 여유 공간
+	FreeInodes     uint64 // 대상 파일시스템 여유 inode
+	InodesKnown    bool   // inode 수를 알 수 있는 파일시스템인지
+}
+
+// SpaceOK reports whether the target has enough free bytes
+func (r PreflightReport) SpaceOK() bool {
+	return r.NeededBytes <= 0 || uint64(r.NeededBytes) <= r.FreeBytes
+}
+
+// InodesOK reports whether the target has enough free inodes (true when unknown)
+func (r PreflightReport) InodesOK() bool {
+	return !r.InodesKnown || r.NeededInodes <= 0 || uint64(r.NeededInodes) <= r.FreeInodes
+}
+
+// Sufficient reports whether the copy is expected to fit on the target
+func (r PreflightReport) Sufficient() bool { return r.SpaceOK() && r.InodesOK() }
+
+// add merges another partial report into r
+func (r *PreflightReport) add(o PreflightReport) {
+	r.Files += o.Files
+	r.SourceBytes += o.SourceBytes
+	r.SkipFiles += o.SkipFiles
+	r.OverwriteFiles += o.OverwriteFiles
+	r.OverwriteBytes += o.OverwriteBytes
+	r.NeededBytes += o.NeededBytes
+	r.NeededInodes += o.NeededInodes
+}
+
+// Preflight sums source sizes (always stat'ing, regardless of scanner size
+// collection), looks at existing targets and compares the net requirement
+// against free space and inodes, returning one report per target filesystem:
+// fan-out roots on the same device share its free space, so their needs are
+// added up and checked once
+func (c *Copier) Preflight(files []string) ([]PreflightReport, error) {
+	roots := c.TargetDirs()
+	reports := make([]PreflightReport, len(roots))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ch := make(chan string, 1024)
+	for i := 0; i < c.workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]PreflightReport, len(roots))
+			for p := range ch {
+				info, err := os.Stat(normalizeLongPath(p))
+				if err != nil {
+					// 읽을 수 없는 파일은 복사 단계에서 실패로 보고됨
+					continue
+				}
+				relPath, ok := c.relPathFor(p)
+				if !ok {
+					continue
+				}
+				for t, root := range roots {
+					r := &local[t]
+					r.Files++
+					r.SourceBytes += info.Size()
+					dstPath := c.lookupPath(root, relPath)
+					if c.plannedSkip(info, root, relPath) {
+						r.SkipFiles++
+						continue
+					}
+					// 백업 모드에서는 기존 파일이 남으므로 공간이 회수되지 않음
+					if dinfo, err := os.Stat(normalizeLongPath(dstPath)); err == nil && dinfo.Mode().IsRegular() && c.backup.Mode == BackupNone {
+						r.OverwriteFiles++
+						r.OverwriteBytes += dinfo.Size()
+						r.NeededBytes += info.Size() - dinfo.Size()
+						continue
+					}
+					r.NeededBytes += info.Size()
+					r.NeededInodes++
+				}
+			}
+			mu.Lock()
+			for t := range reports {
+				reports[t].add(local[t])
+			}
+			mu.Unlock()
+		}()
+	}
+	for _, p := range files {
+		ch <- p
+	}
+	close(ch)
+	wg.Wait()
+
+	var grouped []PreflightReport
+	byFS := make(map[string]int)
+	for t, root := range roots {
+		dir := existingAncestor(root)
+		key := fsKey(dir)
+		if i, ok := byFS[key]; ok {
+			grouped[i].Target += ", " + root
+			grouped[i].add(reports[t])
+			continue
+		}
+		free, inodes, known, err := diskFree(dir)
+		if err != nil {
+			return nil, fmt.Errorf("대상 여유 공간 조회 실패 (%s): %w", root, err)
+		}
+		r := reports[t]
+		r.Target = root
+		r.FreeBytes, r.FreeInodes, r.InodesKnown = free, inodes, known
+		byFS[key] = len(grouped)
+		grouped = append(grouped, r)
+	}
+	return grouped, nil
+}
+
+// relPathFor returns a source file's path relative to the source root
+func (c *Copier) relPathFor(srcPath string) (string, bool) {
+	origSrc := filepath.Clean(srcPath)
+	relPath, err := filepath.Rel(filepath.Clean(c.sourceDir), origSrc)
+	if err != nil {
+		return c.relPathFallback(origSrc)
+	}
+	return relPath, true
+}
+
+// plannedSkip reports whether the copier will not need new space for a file:
+// targets kept by the conflict policy or dropped by a name collision are left
+// alone and files hard-linked from the previous snapshot only add a directory entry
+func (c *Copier) plannedSkip(srcInfo os.FileInfo, root, relPath string) bool {
+	if c.collisionDropped(root, relPath) {
+		return true
+	}
+	if c.keepExisting(srcInfo, c.lookupPath(root, relPath)) {
+		return true
+	}
+	_, unchanged := c.unchangedInSnapshot(srcInfo, root, relPath)
+	return unchanged
+}
+
+// existingAncestor returns the closest existing directory for a (possibly
+// not yet created) target path
+func existingAncestor(p string) string {
+	p = filepath.Clean(p)
+	for {
+		if info, err := os.Stat(normalizeLongPath(p)); err == nil && info.IsDir() {
+			return p
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return p
+		}
+		p = parent
+	}
+}
@@ -0,0 +1,122 @@
+package copier
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Planning looks at targets left in another normalisation form but must not
+// rename them; only the copy itself does
+func TestPreflightDoesNotRename(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	nfc := norm.NFC.String("café/résumé.txt")
+	nfd := norm.NFD.String(nfc)
+	files := writeTree(t, src, map[string]string{nfc: "new content"})
+	writeTree(t, dst, map[string]string{nfd: "old"})
+
+	c := NewCopier(src, dst, false)
+	c.SetNameNorm(NormNFC)
+	reports, err := c.Preflight(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := reports[0]; r.OverwriteFiles != 1 || r.NeededInodes != 0 {
+		t.Errorf("overwrite=%d inodes=%d, want the NFD file counted as overwritten", r.OverwriteFiles, r.NeededInodes)
+	}
+	if !exists(dst, nfd) || exists(dst, nfc) {
+		t.Fatal("preflight renamed the target entry")
+	}
+
+	runCopy(t, c, files)
+	if got := readFile(dst, nfc); got != "new content" {
+		t.Errorf("copy did not move the target to NFC: %q", got)
+	}
+}
+
+// Fan-out roots on one filesystem share its free space, so their needs are
+// summed into one report
+func TestPreflightGroupsSameFilesystem(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	a := filepath.Join(base, "a")
+	b := filepath.Join(base, "b", "not-yet-created")
+	files := writeTree(t, src, map[string]string{"x.bin": "0123456789", "y.bin": "01234"})
+	writeTree(t, a, map[string]string{"x.bin": "0123"}) // 덮어쓰면서 4 B 회수
+
+	c := NewCopier(src, a, false)
+	c.AddTarget(b)
+	reports, err := c.Preflight(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, want one for the shared filesystem: %+v", len(reports), reports)
+	}
+	r := reports[0]
+	if r.Target != a+", "+b {
+		t.Errorf("Target = %q, want both roots", r.Target)
+	}
+	if r.NeededBytes != 15-4+15 || r.NeededInodes != 1+2 || r.OverwriteFiles != 1 {
+		t.Errorf("needed %d B / %d inodes (overwrite %d), want the sum of both roots", r.NeededBytes, r.NeededInodes, r.OverwriteFiles)
+	}
+}
+
+func TestPreflightAccounting(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(c *Copier)
+		needBytes int64
+		inodes    int64
+		skip      int64
+	}{
+		// new.bin 10 B 새로 생성, same.bin 6 B 를 3 B 대상 위에 덮어씀
+		{"overwrite reclaims old size", func(c *Copier) {}, 10 + 6 - 3, 1, 0},
+		{"skip policy needs nothing for existing", func(c *Copier) { c.SetConflictPolicy(ConflictSkip) }, 10, 1, 1},
+		{"backups keep the old file", func(c *Copier) { c.SetBackup(BackupOptions{Mode: BackupSuffix}) }, 10 + 6, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			src := filepath.Join(base, "src")
+			dst := filepath.Join(base, "dst")
+			files := writeTree(t, src, map[string]string{"new.bin": "0123456789", "same.bin": "012345"})
+			writeTree(t, dst, map[string]string{"same.bin": "old"})
+
+			c := NewCopier(src, dst, false)
+			tt.setup(c)
+			reports, err := c.Preflight(files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := reports[0]
+			if r.Files != 2 || r.SourceBytes != 16 || r.NeededBytes != tt.needBytes || r.NeededInodes != tt.inodes || r.SkipFiles != tt.skip {
+				t.Errorf("report %+v, want needed %d B / %d inodes, %d skipped", r, tt.needBytes, tt.inodes, tt.skip)
+			}
+			if r.FreeBytes == 0 || !r.Sufficient() {
+				t.Errorf("free space not looked up or insufficient: %+v", r)
+			}
+		})
+	}
+}
+
+func TestPreflightReportChecks(t *testing.T) {
+	tests := []struct {
+		r             PreflightReport
+		space, inodes bool
+	}{
+		{PreflightReport{NeededBytes: 100, FreeBytes: 100}, true, true},
+		{PreflightReport{NeededBytes: 101, FreeBytes: 100}, false, true},
+		{PreflightReport{NeededBytes: -50}, true, true}, // 덮어쓰기로 오히려 공간이 늘어남
+		{PreflightReport{NeededInodes: 5, FreeInodes: 4, InodesKnown: true}, true, false},
+		{PreflightReport{NeededInodes: 5, FreeInodes: 4}, true, true}, // inode 수를 모르는 파일시스템
+	}
+	for _, tt := range tests {
+		if tt.r.SpaceOK() != tt.space || tt.r.InodesOK() != tt.inodes || tt.r.Sufficient() != (tt.space && tt.inodes) {
+			t.Errorf("%+v: space=%v inodes=%v, want %v %v", tt.r, tt.r.SpaceOK(), tt.r.InodesOK(), tt.space, tt.inodes)
+		}
+	}
+}
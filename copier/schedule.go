@@ -0,0 +1,158 @@
+package copier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Schedule selects the order in which files are handed to workers
+type Schedule int
+
+const (
+	ScheduleDiscovery  Schedule = iota // 스캐너 발견 순서 (기본)
+	ScheduleSmallFirst                 // 작은 파일 먼저: 파일 수를 빠르게 확보
+	ScheduleLargeFirst                 // 큰 파일 먼저: 마지막에 긴 꼬리 방지
+	ScheduleLocality                   // 디렉터리 단위로 모아서 순서대로
+	ScheduleTwoLane                    // 큰 파일 전용 워커 + 작은 파일 워커 분리
+)
+
+const defaultLargeFileThreshold = 64 * 1024 * 1024
+
+var scheduleNames = map[Schedule]string{
+	ScheduleDiscovery:  "discovery",
+	ScheduleSmallFirst: "small-first",
+	ScheduleLargeFirst: "large-first",
+	ScheduleLocality:   "locality",
+	ScheduleTwoLane:    "two-lane",
+}
+
+// String returns the flag name of the schedule
+func (s Schedule) String() string {
+	if name, ok := scheduleNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Schedule(%d)", int(s))
+}
+
+// ParseSchedule converts a flag value into a Schedule
+func ParseSchedule(name string) (Schedule, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ScheduleDiscovery, nil
+	}
+	for s, n := range scheduleNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return ScheduleDiscovery, fmt.Errorf("알 수 없는 스케줄: %s (discovery, small-first, large-first, locality, two-lane)", name)
+}
+
+// SetSchedule selects the work queue ordering (call before CopyFilesParallel)
+func (c *Copier) SetSchedule(s Schedule) { c.schedule = s }
+
+// SetLargeFileThreshold sets the size from which a file counts as large in two-lane mode
+func (c *Copier) SetLargeFileThreshold(bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+	c.largeThreshold = bytes
+}
+
+// needsSizes reports whether the schedule has to know file sizes up front
+func (c *Copier) needsSizes() bool {
+	switch c.schedule {
+	case ScheduleSmallFirst, ScheduleLargeFirst, ScheduleTwoLane:
+		return true
+	}
+	return false
+}
+
+// statSizes looks up file sizes in parallel; unreadable files count as 0 and
+// fail later with a proper error during the copy
+func (c *Copier) statSizes(files []string) map[string]int64 {
+	sizes := make(map[string]int64, len(files))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ch := make(chan string, 1024)
+	for i := 0; i < c.workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range ch {
+				var size int64
+				if info, err := os.Lstat(normalizeLongPath(p)); err == nil {
+					size = info.Size()
+				}
+				mu.Lock()
+				sizes[p] = size
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, p := range files {
+		ch <- p
+	}
+	close(ch)
+	wg.Wait()
+	return sizes
+}
+
+// orderFiles returns the files in schedule order; in two-lane mode the second
+// slice holds the large files
+func (c *Copier) orderFiles(files []string) ([]string, []string) {
+	if c.schedule == ScheduleDiscovery {
+		return files, nil
+	}
+	ordered := make([]string, len(files))
+	copy(ordered, files)
+
+	if c.schedule == ScheduleLocality {
+		sort.SliceStable(ordered, func(i, j int) bool {
+			di, dj := filepath.Dir(ordered[i]), filepath.Dir(ordered[j])
+			if di != dj {
+				return di < dj
+			}
+			return ordered[i] < ordered[j]
+		})
+		return ordered, nil
+	}
+
+	sizes := c.statSizes(ordered)
+	switch c.schedule {
+	case ScheduleSmallFirst:
+		sort.SliceStable(ordered, func(i, j int) bool { return sizes[ordered[i]] < sizes[ordered[j]] })
+	case ScheduleLargeFirst:
+		sort.SliceStable(ordered, func(i, j int) bool { return sizes[ordered[i]] > sizes[ordered[j]] })
+	case ScheduleTwoLane:
+		threshold := c.largeThreshold
+		if threshold <= 0 {
+			threshold = defaultLargeFileThreshold
+		}
+		var small, large []string
+		for _, p := range ordered {
+			if sizes[p] >= threshold {
+				large = append(large, p)
+			} else {
+				small = append(small, p)
+			}
+		}
+		// 큰 파일 레인은 큰 것부터 처리해 꼬리를 줄임
+		sort.SliceStable(large, func(i, j int) bool { return sizes[large[i]] > sizes[large[j]] })
+		return small, large
+	}
+	return ordered, nil
+}
+
+// largeLaneEvery spaces the large-file workers: every fourth worker id,
+// starting at 0, so any active set (ids below the active count, which
+// autotune grows and shrinks) keeps about a quarter on the large lane and
+// the rest on small files
+const largeLaneEvery = 4
+
+// largeLaneWorker reports whether worker id drains the large-file lane first
+func largeLaneWorker(id int) bool { return id%largeLaneEvery == 0 }
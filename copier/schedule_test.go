@@ -0,0 +1,90 @@
+package copier
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderFiles(t *testing.T) {
+	root := t.TempDir()
+	files := writeTree(t, root, map[string]string{
+		"b/mid.bin":   strings.Repeat("m", 50),
+		"a/big.bin":   strings.Repeat("b", 100),
+		"b/small.bin": "s",
+		"a/tiny.bin":  "",
+	})
+	// 발견 순서를 흉내 내어 디렉터리가 섞인 목록
+	discovered := []string{files[2], files[0], files[3], files[1]}
+	rel := func(paths []string) []string {
+		if paths == nil {
+			return nil
+		}
+		out := make([]string, len(paths))
+		for i, p := range paths {
+			r, _ := filepath.Rel(root, p)
+			out[i] = filepath.ToSlash(r)
+		}
+		return out
+	}
+
+	tests := []struct {
+		schedule    Schedule
+		want, large []string
+	}{
+		{ScheduleDiscovery, []string{"b/mid.bin", "a/big.bin", "b/small.bin", "a/tiny.bin"}, nil},
+		{ScheduleSmallFirst, []string{"a/tiny.bin", "b/small.bin", "b/mid.bin", "a/big.bin"}, nil},
+		{ScheduleLargeFirst, []string{"a/big.bin", "b/mid.bin", "b/small.bin", "a/tiny.bin"}, nil},
+		{ScheduleLocality, []string{"a/big.bin", "a/tiny.bin", "b/mid.bin", "b/small.bin"}, nil},
+		{ScheduleTwoLane, []string{"b/small.bin", "a/tiny.bin"}, []string{"a/big.bin", "b/mid.bin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule.String(), func(t *testing.T) {
+			c := NewCopier(root, t.TempDir(), false)
+			c.SetSchedule(tt.schedule)
+			c.SetLargeFileThreshold(50)
+			order, large := c.orderFiles(discovered)
+			if got := rel(order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %q, want %q", got, tt.want)
+			}
+			if got := rel(large); !reflect.DeepEqual(got, tt.large) {
+				t.Errorf("large lane = %q, want %q", got, tt.large)
+			}
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	for s, name := range scheduleNames {
+		got, err := ParseSchedule(" " + strings.ToUpper(name) + " ")
+		if err != nil || got != s {
+			t.Errorf("ParseSchedule(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseSchedule("random"); err == nil {
+		t.Error("unknown schedule accepted")
+	}
+}
+
+// Two-lane runs copy every file whichever lane it was put in
+func TestTwoLaneCopiesAll(t *testing.T) {
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	dst := filepath.Join(base, "dst")
+	files := writeTree(t, src, map[string]string{"big1": strings.Repeat("x", 100), "big2": strings.Repeat("y", 200), "s1": "a", "s2": "b", "s3": "c"})
+
+	c := NewCopier(src, dst, false)
+	c.SetWorkerCount(5)
+	c.SetSchedule(ScheduleTwoLane)
+	c.SetLargeFileThreshold(100)
+	if got := len(runCopy(t, c, files)); got != len(files) {
+		t.Fatalf("got %d results, want %d", got, len(files))
+	}
+	for _, f := range files {
+		name := filepath.Base(f)
+		if !exists(dst, name) {
+			t.Errorf("%s not copied", name)
+		}
+	}
+}
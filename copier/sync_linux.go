@@ -0,0 +1,19 @@
+//go:build linux
+
+package copier
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// syncFilesystem flushes the filesystem containing dir (syncfs)
+func syncFilesystem(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return unix.Syncfs(int(f.Fd()))
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !windows
+
+package copier
+
+import "golang.org/x/sys/unix"
+
+// syncFilesystem flushes all filesystems (no per-filesystem syncfs here)
+func syncFilesystem(dir string) error {
+	unix.Sync()
+	return nil
+}
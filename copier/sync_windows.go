@@ -0,0 +1,10 @@
+//go:build windows
+
+package copier
+
+import "errors"
+
+// syncFilesystem is unsupported on Windows without administrator volume access
+func syncFilesystem(dir string) error {
+	return errors.New("배치 동기화는 Windows에서 지원되지 않음")
+}
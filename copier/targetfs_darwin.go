@@ -0,0 +1,24 @@
+//go:build darwin
+
+package copier
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// detectTargetFS returns the filesystem type of dir and whether it needs
+// Windows name rules (FAT, exFAT, NTFS)
+func detectTargetFS(dir string) (string, bool) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return "unknown", false
+	}
+	name := unix.ByteSliceToString(st.Fstypename[:])
+	switch strings.ToLower(name) {
+	case "msdos", "exfat", "ntfs":
+		return name, true
+	}
+	return name, false
+}
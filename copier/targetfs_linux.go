@@ -0,0 +1,71 @@
+//go:build linux
+
+package copier
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// windowsFSTypes are mount types that apply Windows name rules
+var windowsFSTypes = map[string]bool{
+	"vfat": true, "msdos": true, "exfat": true, "ntfs": true, "ntfs3": true, "fuseblk": true,
+}
+
+// detectTargetFS returns the filesystem type of dir from /proc/self/mounts
+// and whether it needs Windows name rules
+func detectTargetFS(dir string) (string, bool) {
+	if real, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = real
+	}
+	dir, _ = filepath.Abs(dir)
+	f, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		return "unknown", false
+	}
+	defer f.Close()
+	best, fsType := -1, "unknown"
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		// 마운트 경로의 공백 등은 \040 형태로 이스케이프됨
+		mnt := unescapeMount(fields[1])
+		if !within(dir, mnt) || len(mnt) < best {
+			continue
+		}
+		best, fsType = len(mnt), fields[2]
+	}
+	return fsType, windowsFSTypes[fsType]
+}
+
+// within reports whether p is mnt or below it
+func within(p, mnt string) bool {
+	if mnt == "/" || p == mnt {
+		return true
+	}
+	return strings.HasPrefix(p, mnt+"/")
+}
+
+// unescapeMount decodes the octal escapes used in /proc/self/mounts
+func unescapeMount(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && isOctal(s[i+1]) && isOctal(s[i+2]) && isOctal(s[i+3]) {
+			b.WriteByte((s[i+1]-'0')<<6 | (s[i+2]-'0')<<3 | (s[i+3] - '0'))
+			i += 3
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isOctal(c byte) bool { return c >= '0' && c <= '7' }
@@ -0,0 +1,6 @@
+//go:build !linux && !darwin && !windows
+
+package copier
+
+// detectTargetFS assumes POSIX names where the type cannot be detected
+func detectTargetFS(dir string) (string, bool) { return "unknown", false }
@@ -0,0 +1,6 @@
+//go:build windows
+
+package copier
+
+// detectTargetFS always applies Windows name rules on Windows
+func detectTargetFS(dir string) (string, bool) { return "windows", true }
@@ -0,0 +1,65 @@
+package copier
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// RenameTranscode is recorded in TargetResult.Renamed for names converted
+// from the legacy encoding
+const RenameTranscode = "transcode"
+
+// legacyEncodings are the names accepted besides the WHATWG labels
+var legacyEncodings = map[string]encoding.Encoding{
+	"cp949": korean.EUCKR, "uhc": korean.EUCKR, "euc-kr": korean.EUCKR, "euckr": korean.EUCKR,
+	"shift-jis": japanese.ShiftJIS, "shift_jis": japanese.ShiftJIS, "sjis": japanese.ShiftJIS, "cp932": japanese.ShiftJIS, "euc-jp": japanese.EUCJP,
+	"gbk": simplifiedchinese.GBK, "cp936": simplifiedchinese.GBK, "gb18030": simplifiedchinese.GB18030,
+	"big5": traditionalchinese.Big5, "cp950": traditionalchinese.Big5,
+	"cp1252": charmap.Windows1252, "latin1": charmap.ISO8859_1, "cp1251": charmap.Windows1251,
+	"cp437": charmap.CodePage437, "cp850": charmap.CodePage850,
+}
+
+// ParseNameEncoding converts a flag value into the legacy encoding of source
+// names; "" and "none" (or UTF-8) return nil, meaning names are not converted
+func ParseNameEncoding(name string) (encoding.Encoding, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	switch key {
+	case "", "none", "utf-8", "utf8":
+		return nil, nil
+	}
+	if enc, ok := legacyEncodings[key]; ok {
+		return enc, nil
+	}
+	if enc, err := htmlindex.Get(key); err == nil {
+		return enc, nil
+	}
+	return nil, fmt.Errorf("알 수 없는 파일 이름 인코딩: %s (예: cp949, shift-jis, gbk, big5, cp1252)", name)
+}
+
+// SetNameEncoding converts source names that are not valid UTF-8 from enc
+// into UTF-8 on the target; valid UTF-8 names are left alone and names enc
+// cannot decode keep their original bytes (nil disables, call before copying)
+func (c *Copier) SetNameEncoding(enc encoding.Encoding) { c.nameEnc = enc }
+
+// transcodeName decodes one legacy-encoded path component
+func (c *Copier) transcodeName(name string) string {
+	if c.nameEnc == nil || utf8.ValidString(name) {
+		return name
+	}
+	decoded, err := c.nameEnc.NewDecoder().String(name)
+	// 디코딩할 수 없는 바이트는 U+FFFD 로 바뀌므로 원래 이름을 유지
+	if err != nil || !utf8.ValidString(decoded) || strings.ContainsRune(decoded, utf8.RuneError) {
+		c.log.Debug("파일 이름 인코딩 변환 실패", "name", fmt.Sprintf("%q", name))
+		return name
+	}
+	return decoded
+}
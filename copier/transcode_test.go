@@ -0,0 +1,82 @@
+package copier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+)
+
+func TestParseNameEncoding(t *testing.T) {
+	for _, name := range []string{"", "none", "UTF-8"} {
+		if enc, err := ParseNameEncoding(name); enc != nil || err != nil {
+			t.Errorf("ParseNameEncoding(%q) = %v, %v, want no conversion", name, enc, err)
+		}
+	}
+	for name, want := range map[string]any{"cp949": korean.EUCKR, "EUC-KR": korean.EUCKR, "sjis": japanese.ShiftJIS, "shift_jis": japanese.ShiftJIS} {
+		if enc, err := ParseNameEncoding(name); err != nil || enc != want {
+			t.Errorf("ParseNameEncoding(%q) = %v, %v", name, enc, err)
+		}
+	}
+	if _, err := ParseNameEncoding("ebcdic-kr"); err == nil {
+		t.Error("unknown encoding accepted")
+	}
+}
+
+func TestTranscodeName(t *testing.T) {
+	c := NewCopier("", "", false)
+	if got := c.transcodeName("\xb0\xa1"); got != "\xb0\xa1" {
+		t.Errorf("converted %q without an encoding", got)
+	}
+	c.SetNameEncoding(korean.EUCKR)
+	tests := []struct {
+		in, want string
+	}{
+		{"\xb0\xa1\xb3\xaa.txt", "가나.txt"}, // CP949 "가나"
+		{"한글.txt", "한글.txt"},               // 이미 UTF-8 이면 그대로
+		{"\xff\xfe.txt", "\xff\xfe.txt"},   // 디코딩할 수 없으면 원래 바이트 유지
+	}
+	for _, tt := range tests {
+		if got := c.transcodeName(tt.in); got != tt.want {
+			t.Errorf("transcodeName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// CP949 names from an old archive are written in UTF-8 and the result
+// reports the rename
+func TestTranscodeCopy(t *testing.T) {
+	base := t.TempDir()
+	src, dst := filepath.Join(base, "src"), filepath.Join(base, "dst")
+	legacy := filepath.Join(src, "\xb9\xae\xbc\xad", "\xb0\xa1.txt") // 문서/가.txt
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Skip("소스 파일시스템이 UTF-8 이 아닌 이름을 허용하지 않음")
+	}
+	if err := os.WriteFile(legacy, []byte("legacy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := append(writeTree(t, src, map[string]string{"utf8.txt": "ok"}), legacy)
+
+	c := NewCopier(src, dst, false)
+	c.SetNameEncoding(korean.EUCKR)
+	for _, r := range runCopy(t, c, files) {
+		if !r.Success {
+			t.Fatalf("%s: %v", r.FilePath, r.Error)
+		}
+		want := ""
+		if r.FilePath == legacy {
+			want = RenameTranscode
+		}
+		if got := r.Targets[0].Renamed; got != want {
+			t.Errorf("%q: Renamed = %q, want %q", r.FilePath, got, want)
+		}
+	}
+	if readFile(dst, "문서/가.txt") != "legacy" || readFile(dst, "utf8.txt") != "ok" {
+		t.Error("target names not converted to UTF-8")
+	}
+	if exists(dst, "\xb9\xae\xbc\xad") {
+		t.Error("legacy-encoded directory created in the target")
+	}
+}
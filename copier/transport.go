@@ -0,0 +1,56 @@
+package copier
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// Transport carries file contents to or from a remote endpoint in place of
+// the local target directory (see package remote)
+type Transport interface {
+	// Pulls reports the direction: true when files flow from the remote end
+	// into the local target, false when local source files are pushed out
+	Pulls() bool
+	// Transfer moves one file. relPath is relative to the source root and
+	// local is the local side (source file when pushing, target file when
+	// pulling); wait blocks while paused and returns false once canceled.
+	// Returns the file size and whether it was skipped as already up to date.
+	Transfer(relPath, local string, buffer []byte, wait func() bool) (size int64, skipped bool, err error)
+}
+
+// SetTransport routes every file through t instead of the local filesystem
+// copy path; workers, scheduling, pause and progress work as usual
+// (call before CopyFilesParallel)
+func (c *Copier) SetTransport(t Transport) { c.transport = t }
+
+// remotePush reports whether the target side is a remote endpoint
+func (c *Copier) remotePush() bool { return c.transport != nil && !c.transport.Pulls() }
+
+// copyViaTransport transfers a single file through the remote transport
+func (c *Copier) copyViaTransport(srcPath, relPath string, buffer []byte) CopyResult {
+	local := normalizeLongPath(srcPath)
+	remote := relPath
+	if c.transport.Pulls() {
+		local = normalizeLongPath(c.TargetPath(c.targetDir, relPath))
+	} else {
+		remote, _ = c.targetRel(c.targetDir, relPath)
+	}
+	size, skipped, err := c.transport.Transfer(filepath.ToSlash(remote), local, buffer, c.waitIfPaused)
+	if err != nil {
+		return CopyResult{
+			FilePath: srcPath,
+			Success:  false,
+			Error:    fmt.Errorf("원격 전송 실패: %w", err),
+		}
+	}
+	if !skipped {
+		atomic.AddInt64(&c.bytesCopied, size)
+	}
+	return CopyResult{
+		FilePath: srcPath,
+		Success:  true,
+		Size:     size,
+		Skipped:  skipped,
+	}
+}
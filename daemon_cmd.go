@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"superfast-copy-util/api"
+	"superfast-copy-util/remote"
+)
+
+// runDaemon implements the `daemon` command: an HTTP control API for jobs
+func runDaemon(args []string) {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	listen := fs.String("listen", "127.0.0.1:7879", "HTTP 수신 주소 (host:port)")
+	token := fs.String("token", os.Getenv("SUPERFAST_API_TOKEN"), "API 인증 토큰 (Authorization: Bearer, 기본: SUPERFAST_API_TOKEN 환경 변수)")
+	var lopts logOptions
+	registerLogFlags(fs, &lopts)
+	_ = fs.Parse(args)
+
+	logger, logCloser, err := openLog(lopts, true)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(2)
+	}
+	defer logCloser.Close()
+
+	if *token == "" {
+		fmt.Println("⚠️  인증 토큰 없이 API 를 엽니다. 신뢰할 수 있는 네트워크에서만 사용하세요. (-token)")
+	}
+	manager := api.NewManager(buildJobCopier)
+	manager.SetLogger(logger)
+	srv := &api.Server{Manager: manager, Token: *token}
+	httpSrv := &http.Server{
+		Addr:              *listen,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Printf("🛰  제어 API 대기 중: http://%s/jobs\n", *listen)
+	logger.Info("제어 API 시작", "listen", *listen)
+	if err := httpSrv.ListenAndServe(); err != nil {
+		logger.Error("제어 API 종료", "error", err)
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// buildJobCopier configures a copier for a daemon job; options are parsed with
+// the same flag definitions as the CLI
+func buildJobCopier(req api.JobRequest) (api.JobSetup, error) {
+	if remote.IsURL(req.Source) || remote.IsURL(req.Target) {
+		return api.JobSetup{}, errors.New("제어 API 작업은 로컬 경로만 지원합니다")
+	}
+	if info, err := os.Stat(req.Source); err != nil || !info.IsDir() {
+		return api.JobSetup{}, fmt.Errorf("소스 디렉토리가 존재하지 않습니다: %s", req.Source)
+	}
+
+	fs := flag.NewFlagSet("job", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var opts copyOptions
+	finishOpts := registerCopyFlags(fs, &opts)
+	names := make([]string, 0, len(req.Options))
+	for name := range req.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]string, 0, len(names))
+	for _, name := range names {
+		args = append(args, "-"+name+"="+req.Options[name])
+	}
+	if err := fs.Parse(args); err != nil {
+		return api.JobSetup{}, fmt.Errorf("옵션 오류: %v", err)
+	}
+	if err := finishOpts(); err != nil {
+		return api.JobSetup{}, err
+	}
+	return api.JobSetup{
+		Copier:  tuneCopierForSystem(req.Source, req.Target, opts),
+		Scanner: newScanner(opts),
+		Stream:  opts.stream,
+
+		Preflight: opts.preflight,
+	}, nil
+}
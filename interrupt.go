@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// handleInterrupt turns the first SIGINT/SIGTERM into a graceful stop (files
+// in progress are finished, the report is still written) and the second into
+// an immediate exit. The returned function restores the default handling.
+func handleInterrupt(cm *CopyManager) func() {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+			return
+		case sig := <-sigCh:
+			fmt.Print("\n⛔ 취소 중... 진행 중인 파일을 마무리합니다. (한 번 더 누르면 즉시 종료)\n")
+			cm.log.Warn("취소 신호", "signal", sig.String())
+			cm.Cancel()
+		}
+		select {
+		case <-done:
+		case sig := <-sigCh:
+			fmt.Print("\n⛔ 강제 종료\n")
+			cm.log.Error("강제 종료", "signal", sig.String())
+			os.Exit(exitCanceled)
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// The first SIGINT cancels the job gracefully: StartCopy returns, files that
+// were started are either complete or absent, and the exit code is 3
+func TestInterruptCancelsGracefully(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("프로세스에 SIGINT 를 보낼 수 없음")
+	}
+	src, dst := t.TempDir(), t.TempDir()
+	data := bytes.Repeat([]byte("x"), 1<<20)
+	for i := 0; i < 20; i++ {
+		if err := os.WriteFile(filepath.Join(src, string(rune('a'+i))+".bin"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts, _, err := parseLayered(t, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := NewCopyManager(src, dst, opts)
+	cm.Pause() // 취소 전에 끝나지 않도록 복사를 멈춰 둠
+	stop := handleInterrupt(cm)
+	defer stop()
+	done := make(chan struct{})
+	go func() {
+		cm.StartCopy()
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("StartCopy did not return after SIGINT")
+	}
+	if got := cm.exitCode(); got != exitCanceled {
+		t.Errorf("exitCode = %d, want %d", got, exitCanceled)
+	}
+	// 쓰다 만 파일이 남지 않음
+	entries, err := os.ReadDir(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		got, err := os.ReadFile(filepath.Join(dst, e.Name()))
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("%s left partially written in the target", e.Name())
+		}
+	}
+}
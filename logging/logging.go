@@ -0,0 +1,82 @@
+// Package logging builds the slog loggers shared by the CLI, TUI and daemon
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Options selects level, format and destination of the log
+type Options struct {
+	Level      slog.Level
+	JSON       bool   // JSON 한 줄 형식 (기본: key=value 텍스트)
+	File       string // 비어 있으면 fallback 출력 사용
+	MaxSizeMB  int    // 이 크기를 넘으면 회전 (0=회전 안 함)
+	MaxBackups int    // 보관할 이전 파일 수 (file.1 ... file.N)
+}
+
+// ParseLevel converts a flag value into a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("알 수 없는 로그 수준: %s (debug, info, warn, error)", name)
+}
+
+// ParseFormat reports whether a format name selects JSON output
+func ParseFormat(name string) (json bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	}
+	return false, fmt.Errorf("알 수 없는 로그 형식: %s (text, json)", name)
+}
+
+// New creates a logger writing to opts.File (rotated) or, without a file,
+// to fallback; a nil fallback yields Discard. The closer flushes the file.
+func New(opts Options, fallback io.Writer) (*slog.Logger, io.Closer, error) {
+	var out io.Writer = fallback
+	var closer io.Closer = nopCloser{}
+	if opts.File != "" {
+		rf, err := OpenRotating(opts.File, int64(opts.MaxSizeMB)*1024*1024, opts.MaxBackups)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, closer = rf, rf
+	}
+	if out == nil {
+		return Discard(), closer, nil
+	}
+	hopts := &slog.HandlerOptions{Level: opts.Level}
+	var h slog.Handler = slog.NewTextHandler(out, hopts)
+	if opts.JSON {
+		h = slog.NewJSONHandler(out, hopts)
+	}
+	return slog.New(h), closer, nil
+}
+
+// Discard returns a logger that drops every record without formatting it
+func Discard() *slog.Logger { return slog.New(discardHandler{}) }
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
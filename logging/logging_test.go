@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLevelAndFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log, closer, err := New(Options{Level: slog.LevelWarn, JSON: true}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer.Close()
+	log.Info("건너뜀")
+	log.Warn("복사 실패", "file", "a.txt", "attempt", 2)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d records, want only the warning: %q", len(lines), buf.String())
+	}
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["level"] != "WARN" || rec["msg"] != "복사 실패" || rec["file"] != "a.txt" || rec["attempt"] != 2.0 {
+		t.Errorf("record %v", rec)
+	}
+
+	buf.Reset()
+	log, _, _ = New(Options{Level: slog.LevelDebug}, &buf)
+	log.Debug("상세", "n", 1)
+	if got := buf.String(); !strings.Contains(got, "level=DEBUG") || !strings.Contains(got, "n=1") {
+		t.Errorf("text record %q", got)
+	}
+}
+
+func TestNewWithoutOutputDiscards(t *testing.T) {
+	log, closer, err := New(Options{Level: slog.LevelDebug}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Enabled(context.Background(), slog.LevelError) || closer.Close() != nil {
+		t.Error("nil fallback should give a discarding logger")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copy.log")
+	r, err := OpenRotating(path, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 60바이트 기록 5개: 매 두 번째 기록마다 회전, 오래된 파일은 2개까지만 남음
+	for i := 0; i < 5; i++ {
+		if _, err := r.Write([]byte(fmt.Sprintf("%-59d\n", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{path: "4", path + ".1": "3", path + ".2": "2"}
+	for p, first := range want {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b) > 100 || !strings.HasPrefix(string(b), first) {
+			t.Errorf("%s = %q, want to start with record %s", filepath.Base(p), b, first)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("more backups kept than configured")
+	}
+	if _, err := r.Write([]byte("x")); err == nil {
+		t.Error("write after Close succeeded")
+	}
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copy.log")
+	if err := os.WriteFile(path, []byte("earlier run\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenRotating(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("this run\n"))
+	r.Close()
+	if b, _ := os.ReadFile(path); string(b) != "earlier run\nthis run\n" {
+		t.Errorf("log = %q", b)
+	}
+}
+
+func TestParseLevelAndFormat(t *testing.T) {
+	for in, want := range map[string]slog.Level{"": slog.LevelInfo, "DEBUG": slog.LevelDebug, "warning": slog.LevelWarn, " error ": slog.LevelError} {
+		if got, err := ParseLevel(in); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v", in, got, err)
+		}
+	}
+	if _, err := ParseLevel("trace"); err == nil {
+		t.Error("unknown level accepted")
+	}
+	if j, err := ParseFormat("JSON"); err != nil || !j {
+		t.Errorf("ParseFormat(JSON) = %v, %v", j, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"runtime"
@@ -12,12 +13,57 @@ import (
 	"time"
 
 	"superfast-copy-util/copier"
+	"superfast-copy-util/logging"
+	"superfast-copy-util/metrics"
+	"superfast-copy-util/remote"
+	"superfast-copy-util/report"
 	"superfast-copy-util/scanner"
 	"superfast-copy-util/ui"
 
 	"golang.org/x/term"
+	"golang.org/x/text/encoding"
 )
 
+// Exit codes of the CLI, for scripts and cron jobs
+const (
+	exitOK       = 0 // 모든 파일 성공 (건너뛴 파일 포함)
+	exitPartial  = 1 // 일부 파일 실패
+	exitFailed   = 2 // 아무것도 복사하지 못함 (옵션 오류, 사전 점검 거부, 모든 파일 실패)
+	exitCanceled = 3 // 사용자가 취소함 (감시 모드를 멈춘 것은 정상 종료)
+)
+
+// copyOptions holds CLI options that tune the copier
+type copyOptions struct {
+	autoTune     bool                   // 처리량 기반 워커/버퍼 자동 조정
+	workers      int                    // 복사 워커 수 (0=자동)
+	bufferMB     int                    // 워커별 버퍼 크기 (MB)
+	scanWorkers  int                    // 스캔 워커 수 (0=환경 변수/자동)
+	filter       scanner.Filter         // 포함/제외 패턴
+	conflict     copier.ConflictPolicy  // 이미 있는 대상 파일 처리
+	targetFS     copier.TargetFS        // 대상 파일 이름 규칙
+	norm         copier.NameNorm        // 대상 파일 이름 정규화 형식
+	nameEnc      encoding.Encoding      // UTF-8 이 아닌 소스 이름의 인코딩 (nil 이면 변환 안 함)
+	collisions   copier.CollisionPolicy // 대상에서 이름이 겹치는 파일 처리
+	schedule     copier.Schedule        // 작업 큐 순서 전략
+	largeMB      int                    // two-lane 에서 큰 파일 기준 (MB)
+	cache        copier.CacheMode       // 페이지 캐시 사용 방식
+	durable      copier.Durability      // 동기화(fsync) 수준
+	preflight    copier.PreflightPolicy // 사전 공간 점검 정책
+	move         bool                   // 복사 후 소스 제거 (이동)
+	verify       bool                   // 복사 후 SHA-256 비교
+	stream       bool                   // 스캔과 동시에 복사 (목록 수집 생략)
+	extraTargets []string               // 추가 대상 (한 번 읽어 여러 곳에 기록)
+	backup       copier.BackupOptions   // 덮어쓰기 전 기존 파일 백업
+	linkDest     string                 // 이전 스냅숏 (변경 없는 파일은 하드 링크)
+	times        bool                   // 수정 시각 보존
+	deltaMB      int                    // 델타 전송 최소 파일 크기 (MB, 0=끔)
+	remoteToken  string                 // 원격 서버 인증 토큰
+	compress     bool                   // 원격 전송 압축 협상
+	transport    copier.Transport       // 원격 대상으로 보내기 (push)
+	logger       *slog.Logger           // 진행 이벤트 로그 (nil 이면 기록 안 함)
+	metrics      *metrics.Registry      // Prometheus 메트릭 (nil 이면 수집 안 함)
+}
+
 // CopyManager manages the entire copy process
 type CopyManager struct {
 	scanner      *scanner.Scanner
@@ -31,22 +77,48 @@ type CopyManager struct {
 	startTime    time.Time
 	copyStarted  bool
 	scanStopped  chan struct{}
+	opts         copyOptions
+	aborted      bool          // 사전 점검 실패로 복사하지 않음
+	problems     int           // 출력한 오류 수 (스캔 오류 등 파일 결과 밖의 실패 포함)
+	stopped      chan struct{} // Cancel 시 닫힘 (감시 루프 종료)
+	stopOnce     sync.Once
+	watching     bool            // 감시 모드 (멈출 때까지 실행되므로 중지가 정상 종료)
+	watchKeep    map[string]bool // 감시 시작 전부터 대상에만 있던 항목 (소스 경로, 삭제하지 않음)
+	backups      []string        // "대상 → 백업 위치" (최종 보고용)
+	renamed      []string        // "소스 → 대상" 이름이 바뀐 파일 (최종 보고용)
+	remote       *remote.Client  // 원격 연결 (push/pull)
+	remoteDir    string          // 서버 공개 디렉터리 기준 경로
+	pull         bool            // 원격 소스에서 받기 (로컬 스캔 대신 원격 목록)
+	report       *report.Writer  // 파일별 결과 보고서 (-report)
+	metrics      *jobMetrics     // 파일별 결과 메트릭 (-metrics-listen)
+	log          *slog.Logger
 }
 
 // NewCopyManager creates a new copy manager
-func NewCopyManager(sourceDir, targetDir string) *CopyManager {
+func NewCopyManager(sourceDir, targetDir string, opts copyOptions) *CopyManager {
+	if opts.logger == nil {
+		opts.logger = logging.Discard()
+	}
 	return &CopyManager{
-		scanner:     scanner.NewScanner(),
-		copier:      tuneCopierForSystem(sourceDir, targetDir),
+		scanner:     newScanner(opts),
+		copier:      tuneCopierForSystem(sourceDir, targetDir, opts),
 		sourceDir:   sourceDir,
 		targetDir:   targetDir,
 		startTime:   time.Now(),
 		scanStopped: make(chan struct{}),
+		stopped:     make(chan struct{}),
+		opts:        opts,
+		log:         opts.logger,
 	}
 }
 
 // StartCopy starts the copy process
 func (cm *CopyManager) StartCopy() {
+	if cm.pull {
+		cm.pullFiles()
+		return
+	}
+
 	// 스캔 진행 상황 모니터링
 	cm.wg.Add(1)
 	go cm.monitorScanProgress()
@@ -132,6 +204,11 @@ func (cm *CopyManager) handleErrors() {
 func (cm *CopyManager) copyFiles() {
 	defer cm.wg.Done()
 
+	if cm.opts.stream {
+		cm.streamFiles()
+		return
+	}
+
 	var files []string
 	var totalSize int64
 
@@ -144,6 +221,19 @@ func (cm *CopyManager) copyFiles() {
 	// 총 파일 수와 크기를 copier에 설정
 	cm.copier.SetTotal(int64(len(files)), totalSize)
 
+	// 스캔 중 취소: 복사를 시작하지 않고 copier 채널만 정리
+	if cm.copier.IsCanceled() {
+		cm.mu.Lock()
+		cm.copyStarted = true
+		cm.copyProgress.TotalFiles, cm.copyProgress.TotalSize = int64(len(files)), totalSize
+		cm.mu.Unlock()
+		fmt.Printf("\n스캔 중 취소됨: %d개 파일을 찾았지만 복사하지 않습니다.\n", len(files))
+		cm.copier.CopyFilesParallel(nil)
+		for range cm.copier.Results() {
+		}
+		return
+	}
+
 	// 복사 시작 플래그 설정(스캔 로그 중단)
 	cm.mu.Lock()
 	cm.copyStarted = true
@@ -156,6 +246,28 @@ func (cm *CopyManager) copyFiles() {
 	}
 	fmt.Printf("\n스캔 완료: %d개 파일 수집. 복사 시작...\n", len(files))
 
+	// 대소문자/정규화 차이로 대상에서 겹치는 이름을 미리 찾아 처리 방식 결정
+	if collisions := cm.copier.PlanNames(files); len(collisions) > 0 {
+		lines := make([]string, len(collisions))
+		for i, col := range collisions {
+			lines[i] = fmt.Sprintf("%s ↔ %s → %s", col.Owner, col.Source, col.Target)
+		}
+		fmt.Println("⚠️ 대상에서 이름이 겹치는 파일이 있습니다.")
+		printPaths("이름 충돌 (처리: "+cm.opts.collisions.String()+")", lines)
+	}
+
+	// 사전 점검: 대상 여유 공간/inode 확인
+	if len(files) > 0 && !cm.runPreflight(files) {
+		cm.mu.Lock()
+		cm.aborted = true
+		cm.mu.Unlock()
+		// 빈 목록으로 시작해 copier 채널을 정리
+		cm.copier.CopyFilesParallel(nil)
+		for range cm.copier.Results() {
+		}
+		return
+	}
+
 	// 초기 복사 진행 한 줄(스캔 완료 메시지 뒤에 출력)
 	if len(files) > 0 {
 		// 스캔 진행 고루틴이 종료될 시간 아주 짧게 확보
@@ -169,10 +281,105 @@ func (cm *CopyManager) copyFiles() {
 
 	// 복사 결과 처리
 	for result := range cm.copier.Results() {
-		if !result.Success {
-			cm.onError("복사", fmt.Errorf("파일 복사 실패 %s: %v", result.FilePath, result.Error))
+		cm.reportResult(result)
+	}
+}
+
+// streamFiles pipes scanner output straight into the copier so copying starts
+// immediately; the full file list is never held in memory
+func (cm *CopyManager) streamFiles() {
+	// 스캔 진행 출력은 중단하고 복사 진행 줄에 스캔 상태를 함께 표시
+	cm.mu.Lock()
+	cm.copyStarted = true
+	cm.mu.Unlock()
+	fmt.Println("\n스트리밍 모드: 스캔과 동시에 복사를 시작합니다. (사전 공간 점검/정렬 스케줄 생략, 이름 충돌은 발견 순서로 처리)")
+
+	cm.copier.CopyFromScanner(cm.scanner.Files())
+	for result := range cm.copier.Results() {
+		cm.reportResult(result)
+	}
+}
+
+// runPreflight checks free space on the target; returns false when the copy must not start
+func (cm *CopyManager) runPreflight(files []string) bool {
+	if cm.opts.preflight == copier.PreflightOff {
+		return true
+	}
+	fmt.Println("🔎 사전 점검 중 (대상 여유 공간/inode)...")
+	reports, err := cm.copier.Preflight(files)
+	if err != nil {
+		cm.log.Warn("사전 점검 실패", "error", err)
+		fmt.Printf("⚠️  사전 점검 실패: %v\n", err)
+		return cm.opts.preflight != copier.PreflightRefuse
+	}
+	ok := true
+	for _, r := range reports {
+		cm.log.Info("사전 점검", "target", r.Target, "neededBytes", r.NeededBytes, "freeBytes", r.FreeBytes,
+			"neededInodes", r.NeededInodes, "freeInodes", r.FreeInodes, "sufficient", r.Sufficient())
+		fmt.Printf("   [%s] 필요: %s (소스 %s, 덮어쓰기 %d개로 %s 회수, 건너뜀 %d개) / 여유: %s\n",
+			r.Target, formatBytes(r.NeededBytes), formatBytes(r.SourceBytes), r.OverwriteFiles,
+			formatBytes(r.OverwriteBytes), r.SkipFiles, formatBytes(int64(r.FreeBytes)))
+		if r.InodesKnown {
+			fmt.Printf("   [%s] inode 필요: %d / 여유: %d\n", r.Target, r.NeededInodes, r.FreeInodes)
+		}
+		if !r.SpaceOK() {
+			fmt.Printf("⚠️  [%s] 대상 공간 부족: %s 더 필요합니다.\n", r.Target, formatBytes(r.NeededBytes-int64(r.FreeBytes)))
+		}
+		if !r.InodesOK() {
+			fmt.Printf("⚠️  [%s] 대상 inode 부족: %d개 더 필요합니다.\n", r.Target, uint64(r.NeededInodes)-r.FreeInodes)
 		}
+		ok = ok && r.Sufficient()
+	}
+	if ok {
+		return true
+	}
+	if cm.opts.preflight == copier.PreflightRefuse {
+		cm.log.Error("사전 점검 실패로 복사하지 않음")
+		fmt.Println("❌ 사전 점검 실패로 복사를 시작하지 않습니다. (-preflight warn 으로 강행 가능)")
+		return false
 	}
+	fmt.Println("   경고만 하고 계속 진행합니다.")
+	return true
+}
+
+// Aborted reports whether the copy was refused by the pre-flight check
+func (cm *CopyManager) Aborted() bool {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.aborted
+}
+
+// reportResult records displaced backups and prints a failed copy, listing each
+// destination when fanning out
+func (cm *CopyManager) reportResult(result copier.CopyResult) {
+	if cm.report != nil {
+		cm.report.Add(result)
+	}
+	cm.metrics.observe(result)
+	for _, t := range result.Targets {
+		if t.BackupPath != "" {
+			cm.mu.Lock()
+			cm.backups = append(cm.backups, t.Path+" → "+t.BackupPath)
+			cm.mu.Unlock()
+		}
+		if t.Renamed != "" && t.Error == nil && !result.Removed {
+			cm.mu.Lock()
+			cm.renamed = append(cm.renamed, fmt.Sprintf("%q → %s (%s)", result.FilePath, t.Path, t.Renamed))
+			cm.mu.Unlock()
+		}
+	}
+	if result.Success {
+		return
+	}
+	if len(result.Targets) > 1 {
+		for _, t := range result.Targets {
+			if t.Error != nil {
+				cm.onError("복사", fmt.Errorf("파일 복사 실패 %s → %s: %v", result.FilePath, t.Root, t.Error))
+			}
+		}
+		return
+	}
+	cm.onError("복사", fmt.Errorf("파일 복사 실패 %s: %v", result.FilePath, result.Error))
 }
 
 // onScanProgress is called when scan progress updates
@@ -200,9 +407,16 @@ func (cm *CopyManager) onCopyProgress(progress copier.CopyProgress) {
 
 	elapsedSeconds := int(progress.ElapsedTime.Seconds())
 	remainingSeconds := int(progress.RemainingTime.Seconds())
+	label := "복사 중"
+	if progress.Paused {
+		label = "일시정지"
+	} else if !progress.TotalsFinal {
+		label = "복사 중(스캔 진행)"
+	}
 
 	if os.Getenv("SUPERFAST_DEBUG") == "1" {
-		fmt.Printf("\n복사 중: %d/%d개 파일, %.1f%% 완료 (경과: %d초, 남은시간: %d초, 속도: %.1f 파일/초)",
+		fmt.Printf("\n%s: %d/%d개 파일, %.1f%% 완료 (경과: %d초, 남은시간: %d초, 속도: %.1f 파일/초)",
+			label,
 			progress.CompletedFiles,
 			progress.TotalFiles,
 			percent,
@@ -211,7 +425,8 @@ func (cm *CopyManager) onCopyProgress(progress copier.CopyProgress) {
 			progress.Speed)
 		return
 	}
-	fmt.Printf("\r복사 중: %d/%d개 파일, %.1f%% 완료 (경과: %d초, 남은시간: %d초, 속도: %.1f 파일/초)",
+	fmt.Printf("\r%s: %d/%d개 파일, %.1f%% 완료 (경과: %d초, 남은시간: %d초, 속도: %.1f 파일/초)",
+		label,
 		progress.CompletedFiles,
 		progress.TotalFiles,
 		percent,
@@ -222,9 +437,52 @@ func (cm *CopyManager) onCopyProgress(progress copier.CopyProgress) {
 
 // onError is called when an error occurs
 func (cm *CopyManager) onError(operation string, err error) {
+	cm.mu.Lock()
+	cm.problems++
+	cm.mu.Unlock()
 	fmt.Printf("\n%s 오류: %v\n", operation, err)
 }
 
+// exitCode classifies the finished job: canceled, not started or nothing
+// copied, some files failed, or everything succeeded. A watch session only
+// ends when it is stopped, so stopping it is not a cancellation
+func (cm *CopyManager) exitCode() int {
+	p := cm.GetCopyProgress()
+	cm.mu.Lock()
+	problems := cm.problems
+	cm.mu.Unlock()
+	switch {
+	case cm.copier.IsCanceled() && !cm.watching:
+		return exitCanceled
+	case cm.Aborted():
+		return exitFailed
+	case p.FailedFiles == 0 && problems == 0:
+		return exitOK
+	case p.CompletedFiles == 0:
+		return exitFailed
+	}
+	return exitPartial
+}
+
+// Pause pauses the copy phase (workers park between buffer reads)
+func (cm *CopyManager) Pause() { cm.copier.Pause() }
+
+// Resume resumes a paused copy
+func (cm *CopyManager) Resume() { cm.copier.Resume() }
+
+// Cancel stops the job gracefully: scanning stops, files being copied are
+// finished and queued files are left alone
+func (cm *CopyManager) Cancel() {
+	cm.stopOnce.Do(func() {
+		cm.scanner.Cancel()
+		cm.copier.Stop()
+		close(cm.stopped)
+	})
+}
+
+// IsPaused reports whether the copy is paused
+func (cm *CopyManager) IsPaused() bool { return cm.copier.IsPaused() }
+
 // GetScanProgress returns current scan progress
 func (cm *CopyManager) GetScanProgress() scanner.Progress {
 	cm.mu.Lock()
@@ -240,14 +498,83 @@ func (cm *CopyManager) GetCopyProgress() copier.CopyProgress {
 }
 
 func main() {
+	os.Exit(run())
+}
+
+// run executes the command line and returns the process exit code
+func run() int {
+	// serve 명령: 디렉터리를 TCP 로 공개
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return exitOK
+	}
+	// daemon 명령: HTTP 제어 API 로 작업 실행
+	if len(os.Args) > 1 && os.Args[1] == "daemon" {
+		runDaemon(os.Args[2:])
+		return exitOK
+	}
+
 	// 플래그 파싱: 기본은 GUI, --cli 시 CLI 실행
 	cliMode := flag.Bool("cli", false, "CLI 모드로 실행")
 	uiMode := flag.Bool("ui", false, "UI(TUI)로 실행")
+	configPath := flag.String("config", os.Getenv("SUPERFAST_CONFIG"), "작업 프로필 설정 파일 (JSON, 기본: SUPERFAST_CONFIG 또는 사용자 설정 폴더의 superfast-copy-util/config.json)")
+	profileName := flag.String("profile", "", "설정 파일의 작업 프로필 이름 (CLI 모드로 실행)")
+	reportPath := flag.String("report", "", "파일별 결과 보고서를 이 경로에 기록 (작업 정보, 파일별 결과, 합계)")
+	reportFormatName := flag.String("report-format", "auto", "보고서 형식: auto(확장자 .csv 면 csv), jsonl, csv")
+	assumeYes := flag.Bool("yes", false, "묻지 않고 실행: 경로를 입력받지 않고 끝날 때 키 입력을 기다리지 않음 (스크립트/cron 용)")
+	metricsAddr := flag.String("metrics-listen", "", "Prometheus 메트릭을 이 주소의 /metrics 로 제공 (예: :9464, 비우면 끔)")
+	var opts copyOptions
+	finishOpts := registerCopyFlags(flag.CommandLine, &opts)
+	var wopts watchOptions
+	registerWatchFlags(flag.CommandLine, &wopts)
+	var lopts logOptions
+	registerLogFlags(flag.CommandLine, &lopts)
 	flag.Parse()
+	tui := *uiMode || (!*cliMode && *profileName == "")
+
+	// 옵션 우선순위: 명령행 > 환경 변수(SUPERFAST_*) > 설정 파일 프로필 > 기본값
+	var prof *jobProfile
+	if !tui && (*profileName != "" || *configPath != "") {
+		path := *configPath
+		if path == "" {
+			path = defaultConfigPath()
+		}
+		var err error
+		if prof, err = loadProfile(path, *profileName); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return exitFailed
+		}
+	}
+	sources, err := applyLayers(flag.CommandLine, prof)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return exitFailed
+	}
+	logger, logCloser, err := openLog(lopts, !tui)
+	if err != nil {
+		fmt.Printf("❌ %v\n", sources.explain(err))
+		return exitFailed
+	}
+	defer logCloser.Close()
 
-	if *uiMode || !*cliMode {
+	if tui {
+		ui.SetLogger(logger, lopts.forwardArgs())
 		_ = ui.RunTUI()
-		return
+		return exitOK
+	}
+	opts.logger = logger
+	if *metricsAddr != "" {
+		opts.metrics = metrics.NewRegistry()
+	}
+
+	if err := finishOpts(); err != nil {
+		fmt.Printf("❌ %v\n", sources.explain(err))
+		return exitFailed
+	}
+	reportFormat, err := report.ParseFormat(*reportFormatName, *reportPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", sources.explain(&optionError{"report-format", err}))
+		return exitFailed
 	}
 
 	fmt.Println("🚀 SuperFast File Copier")
@@ -259,6 +586,20 @@ func main() {
 	if len(args) >= 2 {
 		sourceDir = strings.TrimSpace(args[0])
 		targetDir = strings.TrimSpace(args[1])
+		// 세 번째 인수부터는 추가 대상 폴더 (한 번 읽어 모두에 기록)
+		for _, extra := range args[2:] {
+			opts.extraTargets = append(opts.extraTargets, strings.TrimSpace(extra))
+		}
+	} else if prof != nil && prof.source != "" && prof.target != "" {
+		// 명령행에 폴더가 없으면 프로필의 폴더 사용
+		if prof.name != "" {
+			fmt.Printf("📋 프로필: %s\n", prof.name)
+		}
+		sourceDir, targetDir = prof.source, prof.target
+		opts.extraTargets = append(opts.extraTargets, prof.targets...)
+	} else if *assumeYes {
+		fmt.Println("❌ -yes 에서는 소스와 타겟 폴더를 인수로 지정해야 합니다. (사용법: superfast-copy-util -cli -yes [옵션] <소스> <타겟> [추가 타겟...])")
+		return exitFailed
 	} else {
 		// 소스 디렉토리 입력 받기
 		fmt.Print("📁 복사할 소스 디렉토리 경로를 입력하세요: ")
@@ -268,7 +609,7 @@ func main() {
 
 		if sourceDir == "" {
 			fmt.Println("❌ 소스 디렉토리 경로가 입력되지 않았습니다.")
-			return
+			return exitFailed
 		}
 
 		// 타겟 디렉토리 입력 받기
@@ -278,27 +619,188 @@ func main() {
 
 		if targetDir == "" {
 			fmt.Println("❌ 타겟 디렉토리 경로가 입력되지 않았습니다.")
-			return
+			return exitFailed
 		}
 	}
 
 	fmt.Println()
 	fmt.Printf("📂 소스: %s\n", sourceDir)
-	fmt.Printf("📂 타겟: %s\n", targetDir)
+	targetList := strings.Join(append([]string{targetDir}, opts.extraTargets...), ", ")
+	fmt.Printf("📂 타겟: %s\n", targetList)
 	fmt.Println()
 
+	// 원격 위치(sfc://host:port/dir): 서버를 통해 받거나 보냄
+	pullURL, pushURL := remote.IsURL(sourceDir), remote.IsURL(targetDir)
+	if wopts.enabled {
+		if names := watchUnsupported(opts, pullURL || pushURL); len(names) > 0 {
+			fmt.Printf("❌ 감시 모드에서는 지원하지 않는 옵션입니다: %s\n", strings.Join(names, ", "))
+			return exitFailed
+		}
+	}
+	var client *remote.Client
+	var remoteDir string
+	if pullURL || pushURL {
+		if pullURL && pushURL {
+			fmt.Println("❌ 소스와 타겟이 모두 원격일 수는 없습니다.")
+			return exitFailed
+		}
+		if names := remoteUnsupported(opts); len(names) > 0 {
+			fmt.Printf("❌ 원격 전송에서는 지원하지 않는 옵션입니다: %s\n", strings.Join(names, ", "))
+			return exitFailed
+		}
+		location := targetDir
+		if pullURL {
+			location = sourceDir
+		}
+		var err error
+		if client, remoteDir, err = connectRemote(location, opts); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return exitFailed
+		}
+		defer client.Close()
+		fmt.Printf("🌐 원격 연결됨 (압축: %s)\n", client.Compression())
+		// 원격 쪽 여유 공간은 알 수 없음
+		opts.preflight = copier.PreflightOff
+		if pushURL {
+			opts.transport = client.Pusher(remoteDir)
+		}
+	}
+
 	// 소스 디렉토리 존재 확인
-	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
+	if _, err := os.Stat(sourceDir); !pullURL && os.IsNotExist(err) {
 		fmt.Printf("❌ 소스 디렉토리가 존재하지 않습니다: %s\n", sourceDir)
-		return
+		return exitFailed
 	}
 
 	// 복사 매니저 생성 및 시작
-	manager := NewCopyManager(sourceDir, targetDir)
+	manager := NewCopyManager(sourceDir, targetDir, opts)
+	manager.remote, manager.remoteDir, manager.pull = client, remoteDir, pullURL
+	if *reportPath != "" {
+		job := report.Job{
+			Source:  sourceDir,
+			Targets: append([]string{targetDir}, opts.extraTargets...),
+			Options: reportOptions(flag.CommandLine),
+			Started: manager.startTime,
+		}
+		if manager.report, err = report.Create(*reportPath, reportFormat, job); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return exitFailed
+		}
+	}
+	if opts.metrics != nil {
+		manager.metrics = newJobMetrics(opts.metrics, manager)
+		addr, err := opts.metrics.Serve(*metricsAddr)
+		if err != nil {
+			fmt.Printf("❌ %v\n", sources.explain(&optionError{"metrics-listen", err}))
+			return exitFailed
+		}
+		fmt.Printf("📈 메트릭: http://%s/metrics\n", addr)
+		logger.Info("메트릭 제공", "listen", addr.String())
+	}
+	logger.Info("작업 시작", "source", sourceDir, "targets", targetList, "watch", wopts.enabled,
+		"options", changedOptions(flag.CommandLine))
+	// Ctrl+Z/fg 등 작업 제어 시그널을 일시정지/재개로 연결
+	stopSignals := watchPauseSignals(manager)
+	// 콘솔에서 P+Enter 로 일시정지/재개 (TUI 가 연 창과 Windows 용)
+	keys, stopKeys := watchPauseKeys(manager)
+	// Ctrl+C/SIGTERM: 진행 중인 파일을 마무리하고 종료 (두 번째는 즉시 종료)
+	stopInterrupt := handleInterrupt(manager)
+	if wopts.enabled {
+		// 감시 모드: 프로세스를 멈출 때까지 변경분을 계속 반영
+		err := manager.Watch(wopts)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+		}
+		stopInterrupt()
+		stopSignals()
+		stopKeys()
+		manager.closeReport()
+		final := manager.GetCopyProgress()
+		logger.Info("감시 종료", "completed", final.CompletedFiles, "failed", final.FailedFiles,
+			"removed", final.RemovedFiles, "elapsed", time.Since(manager.startTime).Round(time.Millisecond))
+		if err != nil {
+			return exitFailed
+		}
+		return manager.exitCode()
+	}
 	manager.StartCopy()
+	stopInterrupt()
+	stopSignals()
+	stopKeys()
+	manager.closeReport()
+	final := manager.GetCopyProgress()
+	logger.Info("작업 종료", "aborted", manager.Aborted(), "canceled", manager.copier.IsCanceled(), "completed", final.CompletedFiles,
+		"failed", final.FailedFiles, "bytes", final.CompletedSize,
+		"elapsed", time.Since(manager.startTime).Round(time.Millisecond))
+
+	// 자동 조정 결정 내역 (진단용)
+	if os.Getenv("SUPERFAST_DEBUG") == "1" {
+		for _, ev := range manager.copier.TuneHistory() {
+			fmt.Printf("\n[autotune] %s", ev)
+		}
+	}
 
 	fmt.Println()
-	fmt.Printf("✅ 복사가 완료되었습니다.\n   - 소스: %s\n   - 타겟: %s\n", sourceDir, targetDir)
+	code := manager.exitCode()
+	if manager.Aborted() {
+		fmt.Printf("❌ 복사를 수행하지 않았습니다.\n   - 소스: %s\n   - 타겟: %s\n", sourceDir, targetList)
+		return code
+	}
+	// 받침에 따라 조사가 달라짐 (복사가 / 이동이)
+	doneLabel, doneSubject := "복사", "복사가"
+	if opts.move {
+		doneLabel, doneSubject = "이동", "이동이"
+	}
+	switch code {
+	case exitOK:
+		fmt.Printf("✅ %s 완료되었습니다.\n", doneSubject)
+	case exitPartial:
+		fmt.Printf("⚠️  %s 끝났지만 일부가 실패했습니다. (실패 %d개)\n", doneSubject, final.FailedFiles)
+	case exitFailed:
+		fmt.Printf("❌ %s에 실패했습니다. (실패 %d개)\n", doneLabel, final.FailedFiles)
+	case exitCanceled:
+		fmt.Printf("⛔ %s 취소되었습니다.\n", doneSubject)
+	}
+	fmt.Printf("   - 소스: %s\n   - 타겟: %s\n", sourceDir, targetList)
+	if code == exitCanceled {
+		manager.printCancelSummary()
+	}
+	fmt.Printf("   - 내구성: %s\n", describeDurability(manager))
+	if final := manager.GetCopyProgress(); final.LinkedFiles > 0 {
+		fmt.Printf("   - 스냅숏 하드 링크: %d개 (%s 절약)\n", final.LinkedFiles, formatBytes(final.LinkedSize))
+	}
+	if final := manager.GetCopyProgress(); final.DeltaFiles > 0 {
+		fmt.Printf("   - 델타 전송: %d개 (%s 재사용)\n", final.DeltaFiles, formatBytes(final.DeltaReused))
+	}
+	if final := manager.GetCopyProgress(); final.SkippedFiles > 0 {
+		fmt.Printf("   - 건너뜀(이미 최신): %d개\n", final.SkippedFiles)
+	}
+	printPaths("백업된 기존 파일", manager.backups)
+	printPaths("이름이 바뀐 파일", manager.renamed)
+	if n := manager.scanner.InvalidNames(); n > 0 && manager.opts.nameEnc == nil {
+		fmt.Printf("   - UTF-8 이 아닌 이름: %d개 (-name-encoding cp949 등으로 변환 가능)\n", n)
+	}
+	if *reportPath != "" {
+		fmt.Printf("   - 보고서: %s (%s)\n", *reportPath, reportFormat)
+	}
+	if !*assumeYes {
+		waitForKey(keys)
+	}
+	return code
+}
+
+// waitForKey keeps the console window open until a key is pressed; without
+// a terminal on stdin (cron, pipes) there is nobody to press it. lines is
+// the console watched by watchPauseKeys, which already owns stdin
+func waitForKey(lines <-chan struct{}) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return
+	}
+	if lines != nil {
+		fmt.Print("계속하려면 Enter 를 누르세요...")
+		<-lines
+		return
+	}
 	fmt.Print("계속하려면 아무 키나 누르세요...")
 	if runtime.GOOS == "windows" {
 		// Windows에서는 cmd의 pause를 이용해 아무 키 입력을 즉시 감지
@@ -310,15 +812,10 @@ func main() {
 	} else {
 		// macOS/Linux: 터미널을 raw 모드로 전환하여 단일 키 입력을 읽음
 		fd := int(os.Stdin.Fd())
-		if term.IsTerminal(fd) {
-			if oldState, err := term.MakeRaw(fd); err == nil {
-				defer term.Restore(fd, oldState)
-				var b [1]byte
-				_, _ = os.Stdin.Read(b[:])
-			} else {
-				reader := bufio.NewReader(os.Stdin)
-				_, _ = reader.ReadBytes('\n')
-			}
+		if oldState, err := term.MakeRaw(fd); err == nil {
+			defer term.Restore(fd, oldState)
+			var b [1]byte
+			_, _ = os.Stdin.Read(b[:])
 		} else {
 			reader := bufio.NewReader(os.Stdin)
 			_, _ = reader.ReadBytes('\n')
@@ -326,8 +823,117 @@ func main() {
 	}
 }
 
+// printCancelSummary lists what a canceled job did and did not copy
+func (cm *CopyManager) printCancelSummary() {
+	p := cm.GetCopyProgress()
+	left := p.TotalFiles - p.CompletedFiles - p.FailedFiles
+	if left < 0 {
+		left = 0
+	}
+	fmt.Printf("   - 완료: %d개 (%s), 실패: %d개, 복사하지 않음: %d개\n",
+		p.CompletedFiles, formatBytes(p.CompletedSize), p.FailedFiles, left)
+	if cm.scanner.Interrupted() {
+		fmt.Println("   - 스캔이 중단되어 소스의 나머지 파일은 확인하지 않았습니다.")
+	}
+}
+
+// closeReport writes the report summary (no-op without -report)
+func (cm *CopyManager) closeReport() {
+	if cm.report == nil {
+		return
+	}
+	if err := cm.report.Close(cm.GetCopyProgress()); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+// changedOptions lists the flags that differ from their defaults (for the
+// log); secrets are left out
+func changedOptions(fs *flag.FlagSet) map[string]string {
+	out := make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name != "token" && f.Value.String() != f.DefValue {
+			out[f.Name] = f.Value.String()
+		}
+	})
+	return out
+}
+
+// reportOptions lists the effective option values for the report header;
+// secrets are left out
+func reportOptions(fs *flag.FlagSet) map[string]string {
+	out := make(map[string]string)
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "token" {
+			return
+		}
+		out[f.Name] = f.Value.String()
+	})
+	return out
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < 0 {
+		return "-" + formatBytes(-n)
+	}
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// printPaths lists paths for the final report (backups, renamed files),
+// capped so huge jobs stay readable; the -report file has every entry
+func printPaths(title string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	const maxListed = 100
+	fmt.Printf("   - %s: %d개\n", title, len(paths))
+	for i, b := range paths {
+		if i == maxListed {
+			fmt.Printf("     ... 외 %d개\n", len(paths)-maxListed)
+			break
+		}
+		fmt.Printf("     %s\n", b)
+	}
+}
+
+// describeDurability summarises what was made durable for the final report
+func describeDurability(cm *CopyManager) string {
+	final := cm.GetCopyProgress()
+	switch mode := cm.copier.Durability(); mode {
+	case copier.DurabilityFile, copier.DurabilityFileDir:
+		return fmt.Sprintf("%s (fsync 완료 %d/%d개)", mode, final.SyncedFiles, final.CompletedFiles)
+	case copier.DurabilityBatch:
+		if err := cm.copier.BatchSyncError(); err != nil {
+			return fmt.Sprintf("%s (동기화 실패: %v)", mode, err)
+		}
+		return fmt.Sprintf("%s (파일시스템 동기화 완료)", mode)
+	default:
+		return "none (동기화하지 않음 - 전원 차단 시 유실 가능)"
+	}
+}
+
+// newScanner creates a scanner with the job's filter and concurrency
+func newScanner(opts copyOptions) *scanner.Scanner {
+	s := scanner.NewScanner()
+	s.SetLogger(opts.logger)
+	s.SetMetrics(opts.metrics)
+	s.SetFilter(opts.filter)
+	s.SetConcurrency(opts.scanWorkers)
+	return s
+}
+
 // tuneCopierForSystem configures copier based on simple system heuristics
-func tuneCopierForSystem(sourceDir, targetDir string) *copier.Copier {
+func tuneCopierForSystem(sourceDir, targetDir string, opts copyOptions) *copier.Copier {
 	c := copier.NewCopier(sourceDir, targetDir, false)
 	// Heuristic: more workers for high CPU count, larger buffer on likely SSD
 	cpu := runtime.NumCPU()
@@ -338,10 +944,43 @@ func tuneCopierForSystem(sourceDir, targetDir string) *copier.Copier {
 	if workers < 4 {
 		workers = 4
 	}
+	if opts.workers > 0 {
+		workers = opts.workers
+	}
 	c.SetWorkerCount(workers)
 
-	// Buffer: default 1MB → bump to 4MB for better throughput
-	c.SetBufferSizeMB(4)
+	// Buffer: default 1MB → bump to 4MB for better throughput (-buffer-mb)
+	c.SetBufferSizeMB(opts.bufferMB)
+
+	// Autotune: start from the heuristic above and explore 1..64 workers
+	if opts.autoTune {
+		c.SetAutoTune(1, 64)
+	}
+
+	c.SetSchedule(opts.schedule)
+	c.SetLargeFileThreshold(int64(opts.largeMB) * 1024 * 1024)
+	c.SetCacheMode(opts.cache)
+	c.SetDurability(opts.durable)
+	c.SetMoveMode(opts.move)
+	c.SetVerify(opts.verify)
+	for _, extra := range opts.extraTargets {
+		c.AddTarget(extra)
+	}
+	c.SetBackup(opts.backup)
+	c.SetConflictPolicy(opts.conflict)
+	c.SetTargetFS(opts.targetFS)
+	c.SetNameNorm(opts.norm)
+	c.SetNameEncoding(opts.nameEnc)
+	c.SetCollisionPolicy(opts.collisions)
+	c.SetFilter(opts.filter)
+	c.SetLogger(opts.logger)
+	c.SetMetrics(opts.metrics)
+	c.SetPreserveTimes(opts.times)
+	c.SetLinkDest(opts.linkDest)
+	c.SetDeltaThreshold(int64(opts.deltaMB) * 1024 * 1024)
+	if opts.transport != nil {
+		c.SetTransport(opts.transport)
+	}
 	return c
 }
 
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Exit codes: 0 everything copied, 1 some files failed, 2 nothing copied,
+// 3 canceled (stopping a watch session is not a cancellation)
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocked []string // 대상에 같은 이름의 디렉터리를 만들어 복사를 실패시킬 파일
+		want    int
+	}{
+		{"all copied", nil, exitOK},
+		{"some failed", []string{"b.txt"}, exitPartial},
+		{"all failed", []string{"a.txt", "b.txt"}, exitFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, dst := t.TempDir(), t.TempDir()
+			for _, name := range []string{"a.txt", "b.txt"} {
+				if err := os.WriteFile(filepath.Join(src, name), []byte(name), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, name := range tt.blocked {
+				if err := os.MkdirAll(filepath.Join(dst, name, "keep"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			opts, _, err := parseLayered(t, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cm := NewCopyManager(src, dst, opts)
+			cm.StartCopy()
+			if got := cm.exitCode(); got != tt.want {
+				p := cm.GetCopyProgress()
+				t.Errorf("exitCode = %d, want %d (completed %d, failed %d)", got, tt.want, p.CompletedFiles, p.FailedFiles)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		opts, _, err := parseLayered(t, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cm := NewCopyManager(t.TempDir(), t.TempDir(), opts)
+		cm.Cancel()
+		if got := cm.exitCode(); got != exitCanceled {
+			t.Errorf("exitCode = %d, want %d", got, exitCanceled)
+		}
+		cm.watching = true
+		if got := cm.exitCode(); got != exitOK {
+			t.Errorf("stopped watch: exitCode = %d, want %d", got, exitOK)
+		}
+	})
+}
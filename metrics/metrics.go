@@ -0,0 +1,244 @@
+// Package metrics keeps counters, gauges and histograms and serves them in
+// the Prometheus text exposition format (no client library needed)
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LatencyBuckets are histogram bounds (seconds) for single I/O calls, from
+// page-cache hits to stalled network shares
+var LatencyBuckets = []float64{
+	0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005,
+	0.01, 0.05, 0.1, 0.5, 1, 5,
+}
+
+// collector writes the samples of one metric family
+type collector interface {
+	collect(w *bufio.Writer, name string)
+}
+
+type family struct {
+	help string
+	kind string // counter, gauge, histogram
+	c    collector
+}
+
+// Registry holds named metrics. Registering a name twice returns the
+// existing metric (counters keep counting across copier runs) except for
+// gauge functions, which are replaced.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// register returns the existing collector for name or stores c
+func (r *Registry) register(name, help, kind string, c collector) collector {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok && f.kind == kind {
+		return f.c
+	}
+	r.families[name] = &family{help: help, kind: kind, c: c}
+	return c
+}
+
+// Counter returns the counter called name
+func (r *Registry) Counter(name, help string) *Counter {
+	c, _ := r.register(name, help, "counter", &Counter{}).(*Counter)
+	return c
+}
+
+// CounterVec returns the counter family called name, split by one label
+func (r *Registry) CounterVec(name, help, label string) *CounterVec {
+	v, _ := r.register(name, help, "counter", &CounterVec{label: label, values: make(map[string]*Counter)}).(*CounterVec)
+	return v
+}
+
+// Histogram returns the histogram called name with the given upper bounds
+func (r *Registry) Histogram(name, help string, buckets []float64) *Histogram {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	h, _ := r.register(name, help, "histogram", &Histogram{bounds: bounds, counts: make([]uint64, len(bounds)+1)}).(*Histogram)
+	return h
+}
+
+// GaugeFunc exposes the value of fn, read at scrape time
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	r.mu.Lock()
+	r.families[name] = &family{help: help, kind: "gauge", c: gaugeFunc(fn)}
+	r.mu.Unlock()
+}
+
+// Handler serves every metric in the text exposition format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		names := make([]string, 0, len(r.families))
+		for name := range r.families {
+			names = append(names, name)
+		}
+		families := make([]*family, len(names))
+		sort.Strings(names)
+		for i, name := range names {
+			families[i] = r.families[name]
+		}
+		r.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		for i, f := range families {
+			fmt.Fprintf(bw, "# HELP %s %s\n", names[i], escapeHelp(f.help))
+			fmt.Fprintf(bw, "# TYPE %s %s\n", names[i], f.kind)
+			f.c.collect(bw, names[i])
+		}
+		_ = bw.Flush()
+	})
+}
+
+// Serve listens on addr and serves /metrics in the background; the returned
+// address has the actual port when addr asks for port 0
+func (r *Registry) Serve(addr string) (net.Addr, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("메트릭 수신 실패: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", r.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	return ln.Addr(), nil
+}
+
+// Counter is a monotonically increasing integer; a nil counter ignores updates
+type Counter struct {
+	v int64
+}
+
+// Add increases the counter by n (n must not be negative)
+func (c *Counter) Add(n int64) {
+	if c != nil && n > 0 {
+		atomic.AddInt64(&c.v, n)
+	}
+}
+
+// Inc adds one
+func (c *Counter) Inc() { c.Add(1) }
+
+func (c *Counter) collect(w *bufio.Writer, name string) {
+	fmt.Fprintf(w, "%s %d\n", name, atomic.LoadInt64(&c.v))
+}
+
+// CounterVec is a set of counters told apart by one label
+type CounterVec struct {
+	label  string
+	mu     sync.Mutex
+	values map[string]*Counter
+}
+
+// With returns the counter for a label value; nil on a nil vector
+func (v *CounterVec) With(value string) *Counter {
+	if v == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	c, ok := v.values[value]
+	if !ok {
+		c = &Counter{}
+		v.values[value] = c
+	}
+	return c
+}
+
+func (v *CounterVec) collect(w *bufio.Writer, name string) {
+	v.mu.Lock()
+	values := make([]string, 0, len(v.values))
+	for value := range v.values {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	counts := make([]int64, len(values))
+	for i, value := range values {
+		counts[i] = atomic.LoadInt64(&v.values[value].v)
+	}
+	v.mu.Unlock()
+	for i, value := range values {
+		fmt.Fprintf(w, "%s{%s=\"%s\"} %d\n", name, v.label, escapeLabel(value), counts[i])
+	}
+}
+
+// Histogram counts observations into cumulative buckets; a nil histogram
+// ignores observations
+type Histogram struct {
+	bounds  []float64
+	counts  []uint64 // 구간별 개수 (마지막은 +Inf), 출력할 때 누적
+	sumBits uint64   // float64 합계 (atomic CAS)
+}
+
+// Observe records one value
+func (h *Histogram) Observe(v float64) {
+	if h == nil {
+		return
+	}
+	atomic.AddUint64(&h.counts[sort.SearchFloat64s(h.bounds, v)], 1)
+	for {
+		old := atomic.LoadUint64(&h.sumBits)
+		sum := math.Float64frombits(old) + v
+		if atomic.CompareAndSwapUint64(&h.sumBits, old, math.Float64bits(sum)) {
+			return
+		}
+	}
+}
+
+// ObserveSince records the time elapsed since start, in seconds
+func (h *Histogram) ObserveSince(start time.Time) {
+	if h != nil {
+		h.Observe(time.Since(start).Seconds())
+	}
+}
+
+func (h *Histogram) collect(w *bufio.Writer, name string) {
+	var total uint64
+	for i, bound := range h.bounds {
+		total += atomic.LoadUint64(&h.counts[i])
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", name, formatFloat(bound), total)
+	}
+	total += atomic.LoadUint64(&h.counts[len(h.bounds)])
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, total)
+	fmt.Fprintf(w, "%s_sum %s\n", name, formatFloat(math.Float64frombits(atomic.LoadUint64(&h.sumBits))))
+	fmt.Fprintf(w, "%s_count %d\n", name, total)
+}
+
+type gaugeFunc func() float64
+
+func (g gaugeFunc) collect(w *bufio.Writer, name string) {
+	fmt.Fprintf(w, "%s %s\n", name, formatFloat(g()))
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T, r *Registry) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestExposition(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("sfc_bytes_total", "복사한 바이트").Add(1024)
+	r.Counter("sfc_bytes_total", "다시 등록해도 같은 카운터").Add(1)
+	r.Counter("sfc_bytes_total", "").Add(-5) // 음수는 무시
+	files := r.CounterVec("sfc_files_total", "파일 수", "outcome")
+	files.With("copied").Add(3)
+	files.With(`we"ird`).Inc()
+	h := r.Histogram("sfc_read_seconds", "읽기 시간\n두 줄", []float64{0.1, 0.01, 1})
+	for _, v := range []float64{0.005, 0.05, 0.05, 2} {
+		h.Observe(v)
+	}
+	r.GaugeFunc("sfc_paused", "일시정지", func() float64 { return 0 })
+	r.GaugeFunc("sfc_paused", "일시정지", func() float64 { return 1 }) // 게이지는 교체됨
+
+	want := `# HELP sfc_bytes_total 복사한 바이트
+# TYPE sfc_bytes_total counter
+sfc_bytes_total 1025
+# HELP sfc_files_total 파일 수
+# TYPE sfc_files_total counter
+sfc_files_total{outcome="copied"} 3
+sfc_files_total{outcome="we\"ird"} 1
+# HELP sfc_paused 일시정지
+# TYPE sfc_paused gauge
+sfc_paused 1
+# HELP sfc_read_seconds 읽기 시간\n두 줄
+# TYPE sfc_read_seconds histogram
+sfc_read_seconds_bucket{le="0.01"} 1
+sfc_read_seconds_bucket{le="0.1"} 3
+sfc_read_seconds_bucket{le="1"} 3
+sfc_read_seconds_bucket{le="+Inf"} 4
+sfc_read_seconds_sum 2.105
+sfc_read_seconds_count 4
+`
+	if got := scrape(t, r); got != want {
+		t.Errorf("exposition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// Nil metrics (instrumentation switched off) ignore updates
+func TestNilMetrics(t *testing.T) {
+	var c *Counter
+	var v *CounterVec
+	var h *Histogram
+	c.Inc()
+	v.With("x").Inc()
+	h.Observe(1)
+}
+
+func TestServe(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("sfc_up", "").Inc()
+	addr, err := r.Serve("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get("http://" + addr.String() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "sfc_up 1\n") {
+		t.Errorf("body %q", body)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"superfast-copy-util/copier"
+	"superfast-copy-util/metrics"
+	"superfast-copy-util/report"
+)
+
+// jobMetrics are the per-file series updated from reportResult; a nil
+// *jobMetrics records nothing
+type jobMetrics struct {
+	files    *metrics.CounterVec // outcome: copied, skipped, linked, delta, moved, removed, failed, canceled
+	bytes    *metrics.Counter
+	failures *metrics.CounterVec // class: report.Classify
+}
+
+// newJobMetrics registers the job series on r, including gauges that read
+// the manager's progress at scrape time
+func newJobMetrics(r *metrics.Registry, cm *CopyManager) *jobMetrics {
+	r.GaugeFunc("sfc_job_start_time_seconds", "작업 시작 시각 (유닉스 초)", func() float64 {
+		return float64(cm.startTime.UnixNano()) / 1e9
+	})
+	r.GaugeFunc("sfc_job_files", "복사할 전체 파일 수 (스캔 중에는 지금까지 발견한 수)", func() float64 {
+		return float64(cm.GetCopyProgress().TotalFiles)
+	})
+	r.GaugeFunc("sfc_job_bytes", "복사할 전체 바이트 (알 수 있을 때)", func() float64 {
+		return float64(cm.GetCopyProgress().TotalSize)
+	})
+	r.GaugeFunc("sfc_job_paused", "일시정지 중이면 1", func() float64 {
+		if cm.IsPaused() {
+			return 1
+		}
+		return 0
+	})
+	return &jobMetrics{
+		files:    r.CounterVec("sfc_files_total", "처리한 파일 수 (결과별)", "outcome"),
+		bytes:    r.Counter("sfc_bytes_copied_total", "실제로 복사한 바이트 (건너뜀/하드 링크 제외, 델타는 새로 쓴 양)"),
+		failures: r.CounterVec("sfc_failures_total", "실패한 파일 수 (오류 종류별)", "class"),
+	}
+}
+
+// observe counts one finished file
+func (m *jobMetrics) observe(result copier.CopyResult) {
+	if m == nil {
+		return
+	}
+	m.files.With(report.Outcome(result)).Inc()
+	m.bytes.Add(result.CopiedBytes())
+	if !result.Success {
+		m.failures.With(report.Classify(result.Error)).Inc()
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"superfast-copy-util/copier"
+	"superfast-copy-util/metrics"
+)
+
+func TestJobMetrics(t *testing.T) {
+	opts, _, err := parseLayered(t, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := NewCopyManager(t.TempDir(), t.TempDir(), opts)
+	reg := metrics.NewRegistry()
+	m := newJobMetrics(reg, cm)
+	for _, r := range []copier.CopyResult{
+		{Success: true, Size: 100},
+		{Success: true, Size: 100, Delta: true, Reused: 60},
+		{Success: true, Size: 100, Skipped: true},
+		{Success: false, Size: 100, Error: fmt.Errorf("열기 실패: %w", fs.ErrNotExist)},
+	} {
+		m.observe(r)
+	}
+	var nilMetrics *jobMetrics
+	nilMetrics.observe(copier.CopyResult{Success: true, Size: 1}) // 메트릭 끔: 무시
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"sfc_bytes_copied_total 140\n",
+		`sfc_files_total{outcome="copied"} 1`,
+		`sfc_files_total{outcome="delta"} 1`,
+		`sfc_files_total{outcome="skipped"} 1`,
+		`sfc_files_total{outcome="failed"} 1`,
+		`sfc_failures_total{class="not-found"} 1`,
+		"sfc_job_paused 0\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
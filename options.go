@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"superfast-copy-util/copier"
+	"superfast-copy-util/logging"
+	"superfast-copy-util/scanner"
+)
+
+// optionError ties a validation failure to the flag that caused it
+type optionError struct {
+	name string
+	err  error
+}
+
+func (e *optionError) Error() string { return "-" + e.name + ": " + e.err.Error() }
+
+func (e *optionError) Unwrap() error { return e.err }
+
+// stringList is a repeatable flag; each value may also hold several
+// comma-separated items (-exclude '*.tmp,cache/')
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// registerCopyFlags defines the copy option flags on fs and returns a function
+// that converts the named values (schedule, cache, ...) after fs.Parse.
+// The CLI and daemon jobs share it so both accept the same options.
+func registerCopyFlags(fs *flag.FlagSet, opts *copyOptions) func() error {
+	fs.BoolVar(&opts.autoTune, "autotune", false, "처리량을 측정해 워커 수/버퍼 크기 자동 조정")
+	fs.IntVar(&opts.workers, "workers", 0, "복사 워커 수 (0=CPU 수에 맞춰 자동)")
+	fs.IntVar(&opts.bufferMB, "buffer-mb", 4, "워커별 복사 버퍼 크기 (MB)")
+	fs.IntVar(&opts.scanWorkers, "scan-workers", 0, "스캔 디렉터리 워커 수 (0=SCANNER_CONCURRENCY 또는 자동)")
+	fs.Var((*stringList)(&opts.filter.Include), "include", "이 패턴에 맞는 파일만 복사 (반복 또는 쉼표 구분, 예: '*.png')")
+	fs.Var((*stringList)(&opts.filter.Exclude), "exclude", "이 패턴에 맞는 항목 제외 (반복 또는 쉼표 구분, 끝이 / 이면 디렉터리, 예: 'cache/')")
+	conflictName := fs.String("conflict", "overwrite", "대상에 같은 파일이 있을 때: overwrite, update(크기/수정 시각이 같으면 건너뜀), skip(있으면 건너뜀); -move 와 함께 쓰면 건너뛴 대상의 내용이 소스와 같을 때만 소스 삭제")
+	targetFSName := fs.String("target-fs", "auto", "대상 파일 이름 규칙: auto(FAT/exFAT/NTFS 감지), posix(그대로), windows(금지 문자/예약 이름 이스케이프), unescape(이스케이프 되돌림)")
+	normName := fs.String("normalize", "none", "대상 파일 이름 유니코드 정규화: none, nfc(조합형, Linux/Windows), nfd(분해형, macOS)")
+	encName := fs.String("name-encoding", "none", "UTF-8 이 아닌 소스 파일 이름의 인코딩: none, cp949, shift-jis, gbk, big5, cp1252 ... (대상에는 UTF-8 로 기록)")
+	collisionName := fs.String("collisions", "fail", "대상에서 이름이 겹치는 파일(대소문자/정규화 차이): fail(실패 처리), rename(' (1)' 접미사), skip(복사 안 함); 경로 순서가 앞선 파일이 이름을 가짐 (-stream 은 발견 순서)")
+	scheduleName := fs.String("schedule", "discovery", "작업 순서: discovery, small-first, large-first, locality, two-lane")
+	fs.IntVar(&opts.largeMB, "large-mb", 64, "two-lane 스케줄에서 큰 파일로 취급할 크기 (MB)")
+	cacheName := fs.String("cache", "normal", "페이지 캐시 모드: normal, dontneed(fadvise), direct(O_DIRECT, Linux 전용)")
+	durabilityName := fs.String("durability", "none", "내구성: none, file(파일 fsync), dir(파일+디렉터리 fsync), batch(종료 시 일괄 동기화)")
+	preflightName := fs.String("preflight", "warn", "사전 공간 점검: warn(경고 후 진행), refuse(부족 시 중단), off")
+	fs.BoolVar(&opts.move, "move", false, "이동 모드: 같은 파일시스템은 rename, 아니면 복사 후 소스 삭제")
+	fs.BoolVar(&opts.verify, "verify", false, "복사 후 소스/대상 SHA-256 비교 (이동 시 삭제 전에 확인)")
+	fs.BoolVar(&opts.stream, "stream", false, "스캔과 동시에 복사 시작 (대용량 공유 폴더용, 메모리 절약; 사전 점검 생략, 이름 충돌은 발견 순서로 처리)")
+	backupName := fs.String("backup", "none", "덮어쓸 기존 파일 백업: none, suffix, numbered, timestamp, dir")
+	fs.StringVar(&opts.backup.Suffix, "backup-suffix", "~", "suffix 백업에 붙일 접미사")
+	fs.StringVar(&opts.backup.Dir, "backup-dir", "", "dir 백업 위치 (상대 경로면 대상 폴더 기준)")
+	fs.StringVar(&opts.linkDest, "link-dest", "", "이전 스냅숏 디렉터리: 크기/수정 시각이 같은 파일은 복사 대신 하드 링크 (상대 경로면 대상 폴더 기준)")
+	fs.BoolVar(&opts.times, "times", false, "소스 파일의 수정 시각을 대상에 보존 (-link-dest 사용 시 자동)")
+	fs.IntVar(&opts.deltaMB, "delta-mb", 0, "이 크기(MB) 이상이고 대상에 이전 버전이 있으면 바뀐 블록만 기록 (0=끔)")
+	fs.StringVar(&opts.remoteToken, "token", os.Getenv("SUPERFAST_TOKEN"), "원격(sfc://) 서버 인증 토큰 (기본: SUPERFAST_TOKEN 환경 변수)")
+	fs.BoolVar(&opts.compress, "compress", true, "원격 전송 시 flate 압축 협상")
+
+	return func() error {
+		var err error
+		if opts.schedule, err = copier.ParseSchedule(*scheduleName); err != nil {
+			return &optionError{"schedule", err}
+		}
+		if opts.cache, err = copier.ParseCacheMode(*cacheName); err != nil {
+			return &optionError{"cache", err}
+		}
+		if opts.durable, err = copier.ParseDurability(*durabilityName); err != nil {
+			return &optionError{"durability", err}
+		}
+		if opts.preflight, err = copier.ParsePreflightPolicy(*preflightName); err != nil {
+			return &optionError{"preflight", err}
+		}
+		if opts.backup.Mode, err = copier.ParseBackupMode(*backupName); err != nil {
+			return &optionError{"backup", err}
+		}
+		if opts.backup.Mode == copier.BackupDir && opts.backup.Dir == "" {
+			return &optionError{"backup", errors.New("-backup dir 에는 -backup-dir 경로가 필요합니다")}
+		}
+		if opts.conflict, err = copier.ParseConflictPolicy(*conflictName); err != nil {
+			return &optionError{"conflict", err}
+		}
+		if opts.targetFS, err = copier.ParseTargetFS(*targetFSName); err != nil {
+			return &optionError{"target-fs", err}
+		}
+		if opts.norm, err = copier.ParseNameNorm(*normName); err != nil {
+			return &optionError{"normalize", err}
+		}
+		if opts.nameEnc, err = copier.ParseNameEncoding(*encName); err != nil {
+			return &optionError{"name-encoding", err}
+		}
+		if opts.collisions, err = copier.ParseCollisionPolicy(*collisionName); err != nil {
+			return &optionError{"collisions", err}
+		}
+		if opts.workers < 0 {
+			return &optionError{"workers", errors.New("0 이상이어야 합니다")}
+		}
+		if opts.scanWorkers < 0 {
+			return &optionError{"scan-workers", errors.New("0 이상이어야 합니다")}
+		}
+		if opts.bufferMB < 1 {
+			return &optionError{"buffer-mb", errors.New("1 이상이어야 합니다")}
+		}
+		if err := (scanner.Filter{Include: opts.filter.Include}).Validate(); err != nil {
+			return &optionError{"include", err}
+		}
+		if err := (scanner.Filter{Exclude: opts.filter.Exclude}).Validate(); err != nil {
+			return &optionError{"exclude", err}
+		}
+		return nil
+	}
+}
+
+// logOptions holds the -log-* flags shared by the CLI, TUI and daemon
+type logOptions struct {
+	file       string
+	level      string
+	format     string
+	maxSizeMB  int
+	maxBackups int
+}
+
+// registerLogFlags defines the logging flags on fs
+func registerLogFlags(fs *flag.FlagSet, lo *logOptions) {
+	fs.StringVar(&lo.file, "log-file", "", "로그 파일 경로 (- 이면 표준 오류, 비우면 기록 안 함; SUPERFAST_DEBUG=1 이면 표준 오류에 debug)")
+	fs.StringVar(&lo.level, "log-level", "info", "로그 수준: debug(파일별 이벤트 포함), info, warn, error")
+	fs.StringVar(&lo.format, "log-format", "text", "로그 형식: text, json")
+	fs.IntVar(&lo.maxSizeMB, "log-max-mb", 100, "로그 파일이 이 크기(MB)를 넘으면 회전 (0=회전 안 함)")
+	fs.IntVar(&lo.maxBackups, "log-backups", 5, "회전 후 보관할 이전 로그 파일 수")
+}
+
+// openLog builds the logger described by lo; allowStderr is false for the
+// TUI, which owns the terminal
+func openLog(lo logOptions, allowStderr bool) (*slog.Logger, io.Closer, error) {
+	level, err := logging.ParseLevel(lo.level)
+	if err != nil {
+		return nil, nil, &optionError{"log-level", err}
+	}
+	jsonFormat, err := logging.ParseFormat(lo.format)
+	if err != nil {
+		return nil, nil, &optionError{"log-format", err}
+	}
+	opts := logging.Options{Level: level, JSON: jsonFormat, File: lo.file, MaxSizeMB: lo.maxSizeMB, MaxBackups: lo.maxBackups}
+	var fallback io.Writer
+	switch {
+	case lo.file == "-":
+		opts.File = ""
+		fallback = os.Stderr
+	case lo.file == "" && os.Getenv("SUPERFAST_DEBUG") == "1":
+		// 예전 디버그 스위치: 표준 오류에 debug 수준으로
+		opts.Level = slog.LevelDebug
+		fallback = os.Stderr
+	}
+	if fallback == os.Stderr && !allowStderr {
+		fallback = nil
+	}
+	return logging.New(opts, fallback)
+}
+
+// forwardArgs returns the log flags for a CLI child process (absolute path,
+// since the child may start in another directory)
+func (lo logOptions) forwardArgs() []string {
+	if lo.file == "" || lo.file == "-" {
+		return nil
+	}
+	file := lo.file
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	return []string{"-log-file=" + file, "-log-level=" + lo.level, "-log-format=" + lo.format,
+		"-log-max-mb=" + strconv.Itoa(lo.maxSizeMB), "-log-backups=" + strconv.Itoa(lo.maxBackups)}
+}
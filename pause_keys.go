@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// watchPauseKeys toggles pause when P and Enter are typed on the console.
+// This is the pause control of the window the TUI opens for a copy (and of
+// Windows, which has no job-control signals). Lines are read in line mode so
+// Ctrl+C keeps working; every other line is passed on to the returned
+// channel for waitForKey, and so is every line once the returned function
+// has stopped the watching. Without a terminal on stdin nothing is read.
+func watchPauseKeys(cm *CopyManager) (<-chan struct{}, func()) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, func() {}
+	}
+	fmt.Println("⏯  P 를 입력하고 Enter 를 누르면 일시정지/재개합니다.")
+	lines := make(chan struct{}, 1)
+	var stopped atomic.Bool
+	go func() {
+		defer close(lines)
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if stopped.Load() || !strings.EqualFold(strings.TrimSpace(line), "p") {
+				select {
+				case lines <- struct{}{}:
+				default:
+				}
+				continue
+			}
+			if cm.IsPaused() {
+				cm.Resume()
+				fmt.Print("\n▶ 재개됨\n")
+			} else {
+				cm.Pause()
+				fmt.Print("\n⏸ 일시정지됨 (P 와 Enter 로 재개)\n")
+			}
+		}
+	}()
+	return lines, func() {
+		stopped.Store(true)
+		// 작업 중에 눌린 Enter 는 버림
+		select {
+		case <-lines:
+		default:
+		}
+	}
+}
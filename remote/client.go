@@ -0,0 +1,334 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// errCanceled is sent to the server when the copier is canceled mid-file
+var errCanceled = errors.New("사용자 취소")
+
+// Client is a connection to a Server; it is safe for concurrent use and every
+// file transfer runs on its own multiplexed stream
+type Client struct {
+	s           *session
+	compression string
+}
+
+// Dial connects to a server and negotiates compression (flate when offered
+// and allowed by the server)
+func Dial(addr, token string, allowCompress bool) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("원격 연결 실패: %v", err)
+	}
+	offer := hello{Version: ProtocolVersion, Token: token, Compress: []string{CompressNone}}
+	if allowCompress {
+		offer.Compress = []string{CompressFlate, CompressNone}
+	}
+	f, err := jsonFrame(frameHello, 0, offer)
+	if err == nil {
+		_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+		if err = writeFrame(conn, f); err == nil {
+			f, err = readFrame(conn)
+		}
+		_ = conn.SetDeadline(time.Time{})
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("원격 협상 실패: %v", err)
+	}
+	var reply hello
+	if f.typ != frameHello || json.Unmarshal(f.payload, &reply) != nil {
+		conn.Close()
+		return nil, errors.New("원격 협상 실패: 잘못된 응답")
+	}
+	if reply.Error != "" {
+		conn.Close()
+		return nil, fmt.Errorf("원격 서버가 연결을 거부함: %s", reply.Error)
+	}
+	s := newSession(conn, 1)
+	s.compress = reply.Chosen == CompressFlate
+	go s.run()
+	return &Client{s: s, compression: reply.Chosen}, nil
+}
+
+// Compression returns the negotiated compression name
+func (c *Client) Compression() string { return c.compression }
+
+// Close closes the connection
+func (c *Client) Close() error {
+	c.s.close(errSessionClosed)
+	return nil
+}
+
+// call opens a stream and sends the request
+func (c *Client) call(req request) (*stream, error) {
+	st, err := c.s.open()
+	if err != nil {
+		return nil, err
+	}
+	if err := st.sendJSON(frameRequest, req); err != nil {
+		st.close()
+		return nil, err
+	}
+	return st, nil
+}
+
+// readEnd waits for the closing frame of a stream
+func readEnd(st *stream) (endInfo, error) {
+	f, err := st.recv()
+	if err != nil {
+		return endInfo{}, err
+	}
+	return parseEnd(f)
+}
+
+func parseEnd(f frame) (endInfo, error) {
+	var end endInfo
+	switch f.typ {
+	case frameEnd:
+		if err := json.Unmarshal(f.payload, &end); err != nil {
+			return end, fmt.Errorf("잘못된 응답: %v", err)
+		}
+		return end, nil
+	case frameError:
+		return end, fmt.Errorf("원격 오류: %s", f.payload)
+	}
+	return end, fmt.Errorf("예상하지 못한 프레임: %d", f.typ)
+}
+
+// List returns every file below dir on the server, paths relative to dir
+func (c *Client) List(dir string) ([]Entry, error) {
+	st, err := c.call(request{Op: "list", Path: cleanRel(dir)})
+	if err != nil {
+		return nil, err
+	}
+	defer st.close()
+	var entries []Entry
+	for {
+		f, err := st.recv()
+		if err != nil {
+			return nil, err
+		}
+		if f.typ != frameData {
+			_, err := parseEnd(f)
+			return entries, err
+		}
+		var batch []Entry
+		if err := json.Unmarshal(f.payload, &batch); err != nil {
+			return nil, fmt.Errorf("잘못된 목록: %v", err)
+		}
+		entries = append(entries, batch...)
+	}
+}
+
+func (c *Client) stat(p string) (endInfo, error) {
+	st, err := c.call(request{Op: "stat", Path: p})
+	if err != nil {
+		return endInfo{}, err
+	}
+	defer st.close()
+	return readEnd(st)
+}
+
+// sameTime compares modification times to the second (filesystems differ in precision)
+func sameTime(a, b int64) bool { return a/int64(time.Second) == b/int64(time.Second) }
+
+// Pusher sends local files into a directory on the server
+type Pusher struct {
+	c   *Client
+	dir string
+}
+
+// Pusher returns a transport that uploads into dir (relative to the served root)
+func (c *Client) Pusher(dir string) *Pusher { return &Pusher{c: c, dir: cleanRel(dir)} }
+
+// Pulls reports the transfer direction (false: local → remote)
+func (p *Pusher) Pulls() bool { return false }
+
+// Transfer uploads the local file to relPath, skipping it when the server
+// already has the same size and mtime and resuming a partial upload
+func (p *Pusher) Transfer(relPath, local string, buffer []byte, wait func() bool) (int64, bool, error) {
+	f, err := os.Open(local)
+	if err != nil {
+		return 0, false, fmt.Errorf("소스 파일 열기 실패: %v", err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, false, fmt.Errorf("파일 정보 읽기 실패: %v", err)
+	}
+	size, mtime := fi.Size(), fi.ModTime().UnixNano()
+	remotePath := joinRel(p.dir, relPath)
+
+	info, err := p.c.stat(remotePath)
+	if err != nil {
+		return 0, false, err
+	}
+	if info.Exists && info.Size == size && sameTime(info.ModTime, mtime) {
+		return size, true, nil
+	}
+	var offset int64
+	if info.Partial > 0 && info.Partial <= size {
+		offset = info.Partial
+	}
+
+	st, err := p.c.call(request{Op: "put", Path: remotePath, Offset: offset, Size: size, ModTime: mtime})
+	if err != nil {
+		return 0, false, err
+	}
+	defer st.close()
+	h, err := resumeHash(f, offset)
+	if err != nil {
+		st.sendError(err)
+		return 0, false, err
+	}
+	chunk := buffer
+	if len(chunk) > dataChunk {
+		chunk = chunk[:dataChunk]
+	}
+	for {
+		if !wait() {
+			st.sendError(errCanceled)
+			return 0, false, errCanceled
+		}
+		if err := st.peerError(); err != nil {
+			return 0, false, err
+		}
+		n, rerr := f.Read(chunk)
+		if n > 0 {
+			h.Write(chunk[:n])
+			if err := st.sendData(chunk[:n]); err != nil {
+				return 0, false, err
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			st.sendError(errCanceled)
+			return 0, false, fmt.Errorf("읽기 실패: %v", rerr)
+		}
+	}
+	if err := st.sendJSON(frameEnd, endInfo{Sum: h.Sum(nil)}); err != nil {
+		return 0, false, err
+	}
+	if _, err := readEnd(st); err != nil {
+		return 0, false, err
+	}
+	return size, false, nil
+}
+
+// Puller fetches files from a directory on the server
+type Puller struct {
+	c       *Client
+	dir     string
+	entries map[string]Entry
+}
+
+// Puller returns a transport that downloads from dir using a listing made by List
+func (c *Client) Puller(dir string, entries []Entry) *Puller {
+	m := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		m[e.Path] = e
+	}
+	return &Puller{c: c, dir: cleanRel(dir), entries: m}
+}
+
+// Pulls reports the transfer direction (true: remote → local)
+func (p *Puller) Pulls() bool { return true }
+
+// Transfer downloads relPath into local through a .sfpart file, skipping files
+// already present with the same size and mtime and resuming a partial download
+func (p *Puller) Transfer(relPath, local string, buffer []byte, wait func() bool) (int64, bool, error) {
+	entry, ok := p.entries[filepath.ToSlash(relPath)]
+	if !ok {
+		return 0, false, fmt.Errorf("원격 목록에 없는 파일: %s", relPath)
+	}
+	if fi, err := os.Stat(local); err == nil && fi.Mode().IsRegular() &&
+		fi.Size() == entry.Size && sameTime(fi.ModTime().UnixNano(), entry.ModTime) {
+		return entry.Size, true, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+		return 0, false, fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+	part := local + partSuffix
+	var offset int64
+	if fi, err := os.Stat(part); err == nil && fi.Size() <= entry.Size {
+		offset = fi.Size()
+	}
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return 0, false, fmt.Errorf("대상 파일 생성 실패: %v", err)
+	}
+	h, err := resumeFile(f, offset)
+	if err != nil {
+		f.Close()
+		return 0, false, err
+	}
+
+	st, err := p.c.call(request{Op: "get", Path: joinRel(p.dir, relPath), Offset: offset})
+	if err != nil {
+		f.Close()
+		return 0, false, err
+	}
+	defer st.close()
+	for {
+		if !wait() {
+			// 미완성 파일은 다음 실행에서 이어받음
+			st.sendError(errCanceled)
+			f.Close()
+			return 0, false, errCanceled
+		}
+		fr, err := st.recv()
+		if err != nil {
+			f.Close()
+			return 0, false, err
+		}
+		if fr.typ == frameData {
+			if _, err := f.Write(fr.payload); err != nil {
+				st.sendError(errCanceled)
+				f.Close()
+				return 0, false, fmt.Errorf("쓰기 실패: %v", err)
+			}
+			h.Write(fr.payload)
+			continue
+		}
+		end, err := parseEnd(fr)
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("파일 닫기 실패: %v", cerr)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if !equalSum(h.Sum(nil), end.Sum) {
+			_ = os.Remove(part)
+			return 0, false, errors.New("체크섬 불일치 (미완성 파일을 지웠으니 다시 시도하세요)")
+		}
+		if err := os.Rename(part, local); err != nil {
+			return 0, false, fmt.Errorf("파일 교체 실패: %v", err)
+		}
+		mtime := time.Unix(0, end.ModTime)
+		_ = os.Chtimes(local, mtime, mtime)
+		return end.Size, false, nil
+	}
+}
+
+// resumeHash hashes the first offset bytes of a freshly opened f, leaving f
+// positioned there
+func resumeHash(f *os.File, offset int64) (hash.Hash, error) {
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, offset); err != nil {
+		return nil, fmt.Errorf("읽기 실패: %v", err)
+	}
+	return h, nil
+}
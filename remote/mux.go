@@ -0,0 +1,206 @@
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// errSessionClosed is returned for streams of a closed connection
+var errSessionClosed = errors.New("연결이 종료됨")
+
+// session multiplexes streams over one connection
+type session struct {
+	conn     net.Conn
+	bw       *bufio.Writer
+	wmu      sync.Mutex
+	compress bool
+
+	mu      sync.Mutex
+	streams map[uint32]*stream
+	nextID  uint32
+	onOpen  func(st *stream, req frame) // 서버: 상대가 연 스트림 처리
+	closed  chan struct{}
+	err     error
+	closing sync.Once
+}
+
+// stream is one request/response exchange inside a session
+type stream struct {
+	id   uint32
+	s    *session
+	in   chan frame
+	done chan struct{}
+	once sync.Once
+}
+
+func newSession(conn net.Conn, firstID uint32) *session {
+	return &session{
+		conn:    conn,
+		bw:      bufio.NewWriterSize(conn, 64*1024),
+		streams: make(map[uint32]*stream),
+		nextID:  firstID,
+		closed:  make(chan struct{}),
+	}
+}
+
+// run reads frames until the connection fails, routing them to streams
+func (s *session) run() {
+	for {
+		f, err := readFrame(s.conn)
+		if err != nil {
+			s.close(err)
+			return
+		}
+		if f.typ == frameData && f.flags&flagCompressed != 0 {
+			if f.payload, err = decompress(f.payload); err != nil {
+				s.close(err)
+				return
+			}
+		}
+		s.mu.Lock()
+		st, ok := s.streams[f.stream]
+		if !ok && f.typ == frameRequest && s.onOpen != nil {
+			st = s.newStreamLocked(f.stream)
+			s.mu.Unlock()
+			go s.onOpen(st, f)
+			continue
+		}
+		s.mu.Unlock()
+		if !ok {
+			// 이미 닫은 스트림의 늦은 프레임은 버림
+			continue
+		}
+		select {
+		case st.in <- f:
+		case <-st.done:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *session) newStreamLocked(id uint32) *stream {
+	st := &stream{id: id, s: s, in: make(chan frame, 64), done: make(chan struct{})}
+	s.streams[id] = st
+	return st
+}
+
+// open starts a new client stream
+func (s *session) open() (*stream, error) {
+	select {
+	case <-s.closed:
+		return nil, s.closeErr()
+	default:
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID += 2
+	return s.newStreamLocked(id), nil
+}
+
+func (s *session) close(err error) {
+	s.closing.Do(func() {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+		close(s.closed)
+		s.conn.Close()
+	})
+}
+
+func (s *session) closeErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		return errSessionClosed
+	}
+	return fmt.Errorf("%w: %v", errSessionClosed, s.err)
+}
+
+func (s *session) write(f frame) error {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	if err := writeFrame(s.bw, f); err != nil {
+		s.close(err)
+		return err
+	}
+	if err := s.bw.Flush(); err != nil {
+		s.close(err)
+		return err
+	}
+	return nil
+}
+
+// send writes a frame on the stream
+func (st *stream) send(typ byte, payload []byte) error {
+	return st.s.write(frame{typ: typ, stream: st.id, payload: payload})
+}
+
+// sendJSON writes a JSON control frame on the stream
+func (st *stream) sendJSON(typ byte, v any) error {
+	f, err := jsonFrame(typ, st.id, v)
+	if err != nil {
+		return err
+	}
+	return st.s.write(f)
+}
+
+// sendData writes file data, compressed when negotiated and worthwhile
+func (st *stream) sendData(p []byte) error {
+	f := frame{typ: frameData, stream: st.id, payload: p}
+	if st.s.compress {
+		if z := compress(p); z != nil {
+			f.payload, f.flags = z, flagCompressed
+		}
+	}
+	return st.s.write(f)
+}
+
+// sendError reports a failure to the peer and closes the stream
+func (st *stream) sendError(err error) {
+	_ = st.send(frameError, []byte(err.Error()))
+	st.close()
+}
+
+// recv waits for the next frame of the stream
+func (st *stream) recv() (frame, error) {
+	select {
+	case f := <-st.in:
+		return f, nil
+	case <-st.s.closed:
+		// 종료 직전에 도착한 프레임 먼저 소비
+		select {
+		case f := <-st.in:
+			return f, nil
+		default:
+		}
+		return frame{}, st.s.closeErr()
+	}
+}
+
+// peerError returns a frame-level error reported by the peer, if any arrived
+func (st *stream) peerError() error {
+	select {
+	case f := <-st.in:
+		if f.typ == frameError {
+			return fmt.Errorf("원격 오류: %s", f.payload)
+		}
+		return fmt.Errorf("예상하지 못한 프레임: %d", f.typ)
+	default:
+		return nil
+	}
+}
+
+// close forgets the stream; frames arriving later are dropped
+func (st *stream) close() {
+	st.once.Do(func() {
+		st.s.mu.Lock()
+		delete(st.s.streams, st.id)
+		st.s.mu.Unlock()
+		close(st.done)
+	})
+}
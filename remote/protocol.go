@@ -0,0 +1,197 @@
+package remote
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// 프로토콜 개요
+//
+// 하나의 TCP 연결 위에 여러 스트림을 다중화한다. 모든 프레임은
+//   [type 1B][flags 1B][stream 4B][length 4B][payload]
+// 형식이며 stream 0 은 연결 협상(hello)에 쓰인다. 클라이언트가 홀수 번호
+// 스트림을 열어 요청(frameRequest)을 보내면 서버가 같은 스트림으로 데이터와
+// 종료(frameEnd) 또는 오류(frameError)를 돌려준다.
+
+const (
+	// ProtocolVersion is bumped on incompatible wire changes
+	ProtocolVersion = 1
+	// DefaultPort is used when a remote URL has no port
+	DefaultPort = "7878"
+	// Scheme prefixes remote locations on the command line
+	Scheme = "sfc://"
+
+	headerSize   = 10
+	maxFrameSize = 16 * 1024 * 1024
+	dataChunk    = 256 * 1024
+	minCompress  = 512 // 이보다 작은 데이터는 압축하지 않음
+
+	// partSuffix marks an incomplete file that a later transfer resumes
+	partSuffix = ".sfpart"
+)
+
+const (
+	frameHello byte = iota + 1
+	frameRequest
+	frameData
+	frameEnd
+	frameError
+)
+
+// flagCompressed marks a data frame whose payload is flate compressed
+const flagCompressed byte = 1
+
+// Compression names offered during the handshake
+const (
+	CompressNone  = "none"
+	CompressFlate = "flate"
+)
+
+type frame struct {
+	typ     byte
+	flags   byte
+	stream  uint32
+	payload []byte
+}
+
+// hello is exchanged once per connection: the client offers, the server chooses
+type hello struct {
+	Version  int      `json:"version"`
+	Token    string   `json:"token,omitempty"`
+	Compress []string `json:"compress,omitempty"`
+	Chosen   string   `json:"chosen,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// request opens a stream
+type request struct {
+	Op      string `json:"op"` // list, stat, get, put
+	Path    string `json:"path"`
+	Offset  int64  `json:"offset,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	ModTime int64  `json:"mtime,omitempty"` // UnixNano
+}
+
+// Entry describes a remote file (slash-separated path relative to the served dir)
+type Entry struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"` // UnixNano
+}
+
+// endInfo closes a stream with the final state of the file
+type endInfo struct {
+	Exists  bool   `json:"exists,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	ModTime int64  `json:"mtime,omitempty"`
+	Partial int64  `json:"partial,omitempty"` // 이어받기용 미완성 파일 크기
+	Sum     []byte `json:"sum,omitempty"`     // 전체 파일 SHA-256
+}
+
+func readFrame(r io.Reader) (frame, error) {
+	var hdr [headerSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return frame{}, err
+	}
+	f := frame{typ: hdr[0], flags: hdr[1], stream: binary.BigEndian.Uint32(hdr[2:6])}
+	n := binary.BigEndian.Uint32(hdr[6:10])
+	if n > maxFrameSize {
+		return frame{}, fmt.Errorf("프레임이 너무 큼: %d", n)
+	}
+	f.payload = make([]byte, n)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return frame{}, err
+	}
+	return f, nil
+}
+
+func writeFrame(w io.Writer, f frame) error {
+	var hdr [headerSize]byte
+	hdr[0], hdr[1] = f.typ, f.flags
+	binary.BigEndian.PutUint32(hdr[2:6], f.stream)
+	binary.BigEndian.PutUint32(hdr[6:10], uint32(len(f.payload)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(f.payload)
+	return err
+}
+
+func jsonFrame(typ byte, stream uint32, v any) (frame, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return frame{}, err
+	}
+	return frame{typ: typ, stream: stream, payload: b}, nil
+}
+
+var flateWriters = sync.Pool{New: func() any {
+	w, _ := flate.NewWriter(nil, flate.BestSpeed)
+	return w
+}}
+
+// compress returns the flate form of p, or nil when it does not shrink
+func compress(p []byte) []byte {
+	if len(p) < minCompress {
+		return nil
+	}
+	var buf bytes.Buffer
+	w := flateWriters.Get().(*flate.Writer)
+	w.Reset(&buf)
+	_, _ = w.Write(p)
+	_ = w.Close()
+	flateWriters.Put(w)
+	if buf.Len() >= len(p) {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func decompress(p []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(p))
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxFrameSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("압축 해제 실패: %v", err)
+	}
+	if len(out) > maxFrameSize {
+		return nil, fmt.Errorf("압축 해제 크기 초과")
+	}
+	return out, nil
+}
+
+// IsURL reports whether s names a remote location (sfc://host[:port]/dir)
+func IsURL(s string) bool { return strings.HasPrefix(s, Scheme) }
+
+// ParseURL splits sfc://host[:port]/dir into a dial address and a directory
+// relative to the served root
+func ParseURL(s string) (addr, dir string, err error) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme+"://" != Scheme || u.Host == "" {
+		return "", "", fmt.Errorf("원격 주소 형식 오류: %s (예: %shost:%s/dir)", s, Scheme, DefaultPort)
+	}
+	addr = u.Host
+	if u.Port() == "" {
+		addr = u.Host + ":" + DefaultPort
+	}
+	return addr, cleanRel(u.Path), nil
+}
+
+// cleanRel normalises a slash path so it cannot climb above the served root
+func cleanRel(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(p)), "/")
+}
+
+// joinRel joins slash paths relative to the served root
+func joinRel(dir, rel string) string {
+	return cleanRel(path.Join(dir, rel))
+}
@@ -0,0 +1,347 @@
+package remote
+
+import (
+	"bytes"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testToken = "secret"
+
+// countingListener counts the bytes servers read from their connections
+type countingListener struct {
+	net.Listener
+	read *int64
+}
+
+func (l countingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return countingConn{Conn: c, read: l.read}, nil
+}
+
+type countingConn struct {
+	net.Conn
+	read *int64
+}
+
+func (c countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	atomic.AddInt64(c.read, int64(n))
+	return n, err
+}
+
+// startServer serves root on a localhost port and returns its address and
+// a counter of the bytes it received
+func startServer(t *testing.T, root string, compress bool) (string, *int64) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("localhost listen unavailable: %v", err)
+	}
+	read := new(int64)
+	srv := &Server{Root: root, Token: testToken, Compress: compress}
+	go srv.Serve(countingListener{Listener: l, read: read})
+	t.Cleanup(func() { l.Close() })
+	return l.Addr().String(), read
+}
+
+func dial(t *testing.T, addr string, compress bool) *Client {
+	t.Helper()
+	c, err := Dial(addr, testToken, compress)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func always() bool { return true }
+
+var testTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// writeFile creates a file with a fixed mtime so skip checks are stable
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, testTime, testTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func randomData(seed int64, n int) []byte {
+	b := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(b)
+	return b
+}
+
+// testFiles span several data frames and include an empty file
+func testFiles() map[string][]byte {
+	return map[string][]byte{
+		"a.txt":          bytes.Repeat([]byte("superfast "), 100000),
+		"sub/random.bin": randomData(1, 3*dataChunk+123),
+		"sub/deep/e.txt": {},
+	}
+}
+
+func checkFile(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s: content differs (len %d, want %d)", path, len(got), len(want))
+	}
+	if _, err := os.Stat(path + partSuffix); !os.IsNotExist(err) {
+		t.Errorf("%s: partial file left behind", path)
+	}
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	in := frame{typ: frameData, flags: flagCompressed, stream: 7, payload: []byte("payload")}
+	if err := writeFrame(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != headerSize+len(in.payload) {
+		t.Fatalf("frame is %d bytes, want %d", buf.Len(), headerSize+len(in.payload))
+	}
+	out, err := readFrame(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.typ != in.typ || out.flags != in.flags || out.stream != in.stream || !bytes.Equal(out.payload, in.payload) {
+		t.Fatalf("got %+v, want %+v", out, in)
+	}
+
+	// 너무 큰 길이는 읽기 전에 거부
+	hdr := make([]byte, headerSize)
+	hdr[0] = frameData
+	hdr[6], hdr[7], hdr[8], hdr[9] = 0xff, 0xff, 0xff, 0xff
+	if _, err := readFrame(bytes.NewReader(hdr)); err == nil {
+		t.Fatal("oversized frame accepted")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	text := bytes.Repeat([]byte("abc"), 10000)
+	packed := compress(text)
+	if packed == nil || len(packed) >= len(text) {
+		t.Fatalf("compressible data not compressed (%d bytes)", len(packed))
+	}
+	out, err := decompress(packed)
+	if err != nil || !bytes.Equal(out, text) {
+		t.Fatalf("decompress: %v", err)
+	}
+	if compress([]byte("short")) != nil {
+		t.Error("data below minCompress was compressed")
+	}
+	if compress(randomData(2, 4096)) != nil {
+		t.Error("incompressible data was compressed")
+	}
+}
+
+func TestCleanRel(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"/", ""},
+		{"a/b", "a/b"},
+		{"/a/./b/", "a/b"},
+		{"../../etc/passwd", "etc/passwd"},
+		{"a/../../b", "b"},
+	}
+	for _, tt := range tests {
+		if got := cleanRel(tt.in); got != tt.want {
+			t.Errorf("cleanRel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBadToken(t *testing.T) {
+	addr, _ := startServer(t, t.TempDir(), true)
+	c, err := Dial(addr, "wrong", true)
+	if err == nil {
+		c.Close()
+		t.Fatal("Dial with a wrong token succeeded")
+	}
+	if !strings.Contains(err.Error(), "인증 토큰 불일치") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// 거부된 뒤에도 서버는 올바른 토큰을 받음
+	dial(t, addr, true)
+}
+
+func TestPushPull(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		name := CompressNone
+		if compress {
+			name = CompressFlate
+		}
+		t.Run(name, func(t *testing.T) {
+			files := testFiles()
+			local, served := t.TempDir(), t.TempDir()
+			for rel, data := range files {
+				writeFile(t, filepath.Join(local, filepath.FromSlash(rel)), data)
+			}
+			addr, received := startServer(t, served, true)
+			c := dial(t, addr, compress)
+			if c.Compression() != name {
+				t.Fatalf("negotiated %s, want %s", c.Compression(), name)
+			}
+
+			push := c.Pusher("backup")
+			var total int
+			for rel, data := range files {
+				total += len(data)
+				n, skipped, err := push.Transfer(filepath.FromSlash(rel), filepath.Join(local, filepath.FromSlash(rel)), make([]byte, 1<<20), always)
+				if err != nil || skipped || n != int64(len(data)) {
+					t.Fatalf("push %s: n=%d skipped=%v err=%v", rel, n, skipped, err)
+				}
+				path := filepath.Join(served, "backup", filepath.FromSlash(rel))
+				checkFile(t, path, data)
+				if fi, _ := os.Stat(path); !fi.ModTime().Equal(testTime) {
+					t.Errorf("%s: mtime %v, want %v", rel, fi.ModTime(), testTime)
+				}
+			}
+			// 압축하면 반복되는 텍스트 덕분에 보낸 양이 원본보다 훨씬 작음
+			if got := atomic.LoadInt64(received); compress && got > int64(total)*3/4 {
+				t.Errorf("server received %d bytes for %d bytes of files with compression", got, total)
+			} else if !compress && got < int64(total) {
+				t.Errorf("server received %d bytes for %d bytes of files without compression", got, total)
+			}
+			for rel := range files {
+				if _, skipped, err := push.Transfer(filepath.FromSlash(rel), filepath.Join(local, filepath.FromSlash(rel)), make([]byte, 1<<20), always); err != nil || !skipped {
+					t.Errorf("second push of %s: skipped=%v err=%v", rel, skipped, err)
+				}
+			}
+
+			entries, err := c.List("backup")
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(entries) != len(files) {
+				t.Fatalf("List returned %d entries, want %d: %+v", len(entries), len(files), entries)
+			}
+			pull := c.Puller("backup", entries)
+			back := t.TempDir()
+			for _, e := range entries {
+				dst := filepath.Join(back, filepath.FromSlash(e.Path))
+				n, skipped, err := pull.Transfer(filepath.FromSlash(e.Path), dst, make([]byte, 1<<20), always)
+				if err != nil || skipped || n != e.Size {
+					t.Fatalf("pull %s: n=%d skipped=%v err=%v", e.Path, n, skipped, err)
+				}
+				checkFile(t, dst, files[e.Path])
+				if _, skipped, err := pull.Transfer(filepath.FromSlash(e.Path), dst, nil, always); err != nil || !skipped {
+					t.Errorf("second pull of %s: skipped=%v err=%v", e.Path, skipped, err)
+				}
+			}
+		})
+	}
+}
+
+func TestPushResume(t *testing.T) {
+	data := randomData(3, 2*dataChunk+500)
+	local, served := t.TempDir(), t.TempDir()
+	src := filepath.Join(local, "f.bin")
+	writeFile(t, src, data)
+	addr, _ := startServer(t, served, false)
+	push := dial(t, addr, false).Pusher("")
+	dst := filepath.Join(served, "f.bin")
+
+	// 앞부분이 다른 미완성 파일: 이어받기를 했다면 체크섬이 맞지 않음
+	bad := append([]byte(nil), data[:dataChunk]...)
+	bad[10] ^= 0xff
+	writeFile(t, dst+partSuffix, bad)
+	if _, _, err := push.Transfer("f.bin", src, make([]byte, 1<<20), always); err == nil || !strings.Contains(err.Error(), "체크섬 불일치") {
+		t.Fatalf("resume over a corrupt partial file: %v", err)
+	}
+	if _, err := os.Stat(dst + partSuffix); !os.IsNotExist(err) {
+		t.Fatal("corrupt partial file was kept")
+	}
+
+	// 올바른 앞부분은 이어서 완성
+	writeFile(t, dst+partSuffix, data[:dataChunk+7])
+	if _, _, err := push.Transfer("f.bin", src, make([]byte, 1<<20), always); err != nil {
+		t.Fatalf("resumed push: %v", err)
+	}
+	checkFile(t, dst, data)
+}
+
+func TestPullResume(t *testing.T) {
+	data := randomData(4, 2*dataChunk+500)
+	served, local := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(served, "f.bin"), data)
+	addr, _ := startServer(t, served, true)
+	c := dial(t, addr, true)
+	entries, err := c.List("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pull := c.Puller("", entries)
+	dst := filepath.Join(local, "f.bin")
+
+	bad := append([]byte(nil), data[:dataChunk]...)
+	bad[10] ^= 0xff
+	writeFile(t, dst+partSuffix, bad)
+	if _, _, err := pull.Transfer("f.bin", dst, nil, always); err == nil || !strings.Contains(err.Error(), "체크섬 불일치") {
+		t.Fatalf("resume over a corrupt partial file: %v", err)
+	}
+	if _, err := os.Stat(dst + partSuffix); !os.IsNotExist(err) {
+		t.Fatal("corrupt partial file was kept")
+	}
+
+	writeFile(t, dst+partSuffix, data[:dataChunk+7])
+	if _, _, err := pull.Transfer("f.bin", dst, nil, always); err != nil {
+		t.Fatalf("resumed pull: %v", err)
+	}
+	checkFile(t, dst, data)
+	if fi, _ := os.Stat(dst); !fi.ModTime().Equal(testTime) {
+		t.Errorf("mtime %v, want %v", fi.ModTime(), testTime)
+	}
+}
+
+// A canceled push leaves the partial file for the next run to resume
+func TestPushCancelKeepsPartial(t *testing.T) {
+	data := randomData(5, 4*dataChunk)
+	local, served := t.TempDir(), t.TempDir()
+	src := filepath.Join(local, "f.bin")
+	writeFile(t, src, data)
+	addr, _ := startServer(t, served, false)
+	push := dial(t, addr, false).Pusher("")
+
+	calls := 0
+	stopAfterTwo := func() bool { calls++; return calls <= 2 }
+	if _, _, err := push.Transfer("f.bin", src, make([]byte, dataChunk), stopAfterTwo); err != errCanceled {
+		t.Fatalf("canceled push returned %v", err)
+	}
+	part := filepath.Join(served, "f.bin"+partSuffix)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		fi, err := os.Stat(part)
+		if err == nil && fi.Size() == 2*dataChunk {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("partial file not kept: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, _, err := push.Transfer("f.bin", src, make([]byte, dataChunk), always); err != nil {
+		t.Fatalf("resumed push: %v", err)
+	}
+	checkFile(t, filepath.Join(served, "f.bin"), data)
+}
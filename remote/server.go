@@ -0,0 +1,337 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Server exposes a directory to remote copiers
+type Server struct {
+	Root     string // 공개할 디렉터리
+	Token    string // 비어 있지 않으면 클라이언트가 같은 토큰을 보내야 함
+	Compress bool   // flate 압축 허용
+	Logf     func(format string, args ...any)
+}
+
+// ListenAndServe listens on addr and serves connections until the listener fails
+func (srv *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("수신 대기 실패: %v", err)
+	}
+	return srv.Serve(l)
+}
+
+// Serve accepts connections on l
+func (srv *Server) Serve(l net.Listener) error {
+	defer l.Close()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go srv.handleConn(conn)
+	}
+}
+
+func (srv *Server) logf(format string, args ...any) {
+	if srv.Logf != nil {
+		srv.Logf(format, args...)
+	}
+}
+
+// handleConn negotiates the connection and then serves its streams
+func (srv *Server) handleConn(conn net.Conn) {
+	peer := conn.RemoteAddr().String()
+	chosen, err := srv.handshake(conn)
+	if err != nil {
+		srv.logf("연결 거부 %s: %v", peer, err)
+		conn.Close()
+		return
+	}
+	srv.logf("연결 %s (압축: %s)", peer, chosen)
+	s := newSession(conn, 0)
+	s.compress = chosen == CompressFlate
+	s.onOpen = srv.handleStream
+	s.run()
+	srv.logf("연결 종료 %s", peer)
+}
+
+// handshake checks version and token and picks the compression
+func (srv *Server) handshake(conn net.Conn) (string, error) {
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	defer conn.SetDeadline(time.Time{})
+	f, err := readFrame(conn)
+	if err != nil {
+		return "", err
+	}
+	var h hello
+	if f.typ != frameHello || json.Unmarshal(f.payload, &h) != nil {
+		return "", errors.New("잘못된 협상 메시지")
+	}
+	reply := hello{Version: ProtocolVersion, Chosen: CompressNone}
+	switch {
+	case h.Version != ProtocolVersion:
+		reply.Error = fmt.Sprintf("프로토콜 버전 불일치 (서버 %d, 클라이언트 %d)", ProtocolVersion, h.Version)
+	case subtle.ConstantTimeCompare([]byte(h.Token), []byte(srv.Token)) != 1:
+		reply.Error = "인증 토큰 불일치"
+	}
+	if srv.Compress {
+		for _, c := range h.Compress {
+			if c == CompressFlate {
+				reply.Chosen = CompressFlate
+				break
+			}
+		}
+	}
+	rf, err := jsonFrame(frameHello, 0, reply)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFrame(conn, rf); err != nil {
+		return "", err
+	}
+	if reply.Error != "" {
+		return "", errors.New(reply.Error)
+	}
+	return reply.Chosen, nil
+}
+
+// resolve maps a slash path from the wire into the served directory
+func (srv *Server) resolve(p string) string {
+	return filepath.Join(srv.Root, filepath.FromSlash(cleanRel(p)))
+}
+
+// handleStream dispatches one request
+func (srv *Server) handleStream(st *stream, f frame) {
+	var req request
+	if err := json.Unmarshal(f.payload, &req); err != nil {
+		st.sendError(fmt.Errorf("잘못된 요청: %v", err))
+		return
+	}
+	var err error
+	switch req.Op {
+	case "list":
+		err = srv.list(st, req)
+	case "stat":
+		err = srv.stat(st, req)
+	case "get":
+		err = srv.get(st, req)
+	case "put":
+		err = srv.put(st, req)
+	default:
+		err = fmt.Errorf("알 수 없는 요청: %s", req.Op)
+	}
+	if err != nil {
+		srv.logf("%s %s 실패: %v", req.Op, req.Path, err)
+		st.sendError(err)
+		return
+	}
+	st.close()
+}
+
+// list sends every regular file below the requested directory in batches
+func (srv *Server) list(st *stream, req request) error {
+	root := srv.resolve(req.Path)
+	var batch []Entry
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		b, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return st.sendData(b)
+	}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root {
+				return err
+			}
+			// 읽을 수 없는 하위 항목은 건너뜀
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(p, partSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		batch = append(batch, Entry{Path: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime().UnixNano()})
+		if len(batch) >= 512 {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("목록 읽기 실패: %v", err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return st.sendJSON(frameEnd, endInfo{})
+}
+
+// stat reports the final file and any partial upload for resume
+func (srv *Server) stat(st *stream, req request) error {
+	p := srv.resolve(req.Path)
+	var info endInfo
+	if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+		info.Exists, info.Size, info.ModTime = true, fi.Size(), fi.ModTime().UnixNano()
+	}
+	if fi, err := os.Stat(p + partSuffix); err == nil && fi.Mode().IsRegular() {
+		info.Partial = fi.Size()
+	}
+	return st.sendJSON(frameEnd, info)
+}
+
+// get streams a file from req.Offset; the digest covers the whole file
+func (srv *Server) get(st *stream, req request) error {
+	f, err := os.Open(srv.resolve(req.Path))
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("파일 정보 읽기 실패: %v", err)
+	}
+	if req.Offset < 0 || req.Offset > fi.Size() {
+		return fmt.Errorf("이어받기 위치가 파일 크기를 벗어남: %d", req.Offset)
+	}
+	h := sha256.New()
+	if _, err := io.CopyN(h, f, req.Offset); err != nil {
+		return fmt.Errorf("읽기 실패: %v", err)
+	}
+	buf := make([]byte, dataChunk)
+	for {
+		// 클라이언트가 중단(취소)했으면 더 보내지 않음
+		if err := st.peerError(); err != nil {
+			return nil
+		}
+		n, rerr := f.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if err := st.sendData(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return fmt.Errorf("읽기 실패: %v", rerr)
+		}
+	}
+	return st.sendJSON(frameEnd, endInfo{Exists: true, Size: fi.Size(), ModTime: fi.ModTime().UnixNano(), Sum: h.Sum(nil)})
+}
+
+// put receives a file into a .sfpart file, appending from req.Offset, and
+// renames it into place once the digest matches
+func (srv *Server) put(st *stream, req request) error {
+	dst := srv.resolve(req.Path)
+	if cleanRel(req.Path) == "" {
+		return errors.New("대상 경로가 비어 있음")
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+	part := dst + partSuffix
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return fmt.Errorf("대상 파일 생성 실패: %v", err)
+	}
+	h, err := resumeFile(f, req.Offset)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	for {
+		fr, err := st.recv()
+		if err != nil {
+			f.Close()
+			return err
+		}
+		switch fr.typ {
+		case frameData:
+			if _, err := f.Write(fr.payload); err != nil {
+				f.Close()
+				return fmt.Errorf("쓰기 실패: %v", err)
+			}
+			h.Write(fr.payload)
+		case frameError:
+			// 클라이언트 중단: 미완성 파일은 이어받기를 위해 남김
+			f.Close()
+			st.close()
+			return nil
+		case frameEnd:
+			var end endInfo
+			if err := json.Unmarshal(fr.payload, &end); err != nil {
+				f.Close()
+				return fmt.Errorf("잘못된 종료 메시지: %v", err)
+			}
+			if cerr := f.Close(); cerr != nil {
+				return fmt.Errorf("파일 닫기 실패: %v", cerr)
+			}
+			if sum := h.Sum(nil); !equalSum(sum, end.Sum) {
+				_ = os.Remove(part)
+				return errors.New("체크섬 불일치 (미완성 파일을 지웠으니 다시 시도하세요)")
+			}
+			if err := os.Rename(part, dst); err != nil {
+				return fmt.Errorf("파일 교체 실패: %v", err)
+			}
+			if req.ModTime != 0 {
+				mtime := time.Unix(0, req.ModTime)
+				_ = os.Chtimes(dst, mtime, mtime)
+			}
+			return st.sendJSON(frameEnd, endInfo{Exists: true, Size: req.Size})
+		default:
+			f.Close()
+			return fmt.Errorf("예상하지 못한 프레임: %d", fr.typ)
+		}
+	}
+}
+
+// resumeFile cuts f to offset, hashes the kept prefix and positions f at its end
+func resumeFile(f *os.File, offset int64) (hash.Hash, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("파일 정보 읽기 실패: %v", err)
+	}
+	if offset < 0 || offset > fi.Size() {
+		return nil, fmt.Errorf("이어받기 위치 불일치 (요청 %d, 보관 %d)", offset, fi.Size())
+	}
+	if err := f.Truncate(offset); err != nil {
+		return nil, fmt.Errorf("미완성 파일 정리 실패: %v", err)
+	}
+	h := sha256.New()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyN(h, f, offset); err != nil {
+		return nil, fmt.Errorf("미완성 파일 읽기 실패: %v", err)
+	}
+	return h, nil
+}
+
+func equalSum(a, b []byte) bool {
+	return len(a) == sha256.Size && subtle.ConstantTimeCompare(a, b) == 1
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"superfast-copy-util/copier"
+	"superfast-copy-util/remote"
+)
+
+// runServe implements the `serve` command: expose a directory over TCP
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", ":"+remote.DefaultPort, "수신 주소 (host:port)")
+	token := fs.String("token", os.Getenv("SUPERFAST_TOKEN"), "클라이언트 인증 토큰 (기본: SUPERFAST_TOKEN 환경 변수)")
+	allowCompress := fs.Bool("compress", true, "클라이언트가 요청하면 flate 압축 사용")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("사용법: superfast-copy-util serve [-listen :7878] [-token T] <디렉터리>")
+		os.Exit(2)
+	}
+	root, err := filepath.Abs(fs.Arg(0))
+	if err == nil {
+		var info os.FileInfo
+		if info, err = os.Stat(root); err == nil && !info.IsDir() {
+			err = fmt.Errorf("디렉터리가 아닙니다: %s", root)
+		}
+	}
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if *token == "" {
+		fmt.Println("⚠️  인증 토큰 없이 서빙합니다. 신뢰할 수 있는 네트워크에서만 사용하세요. (-token)")
+	}
+	srv := &remote.Server{
+		Root:     root,
+		Token:    *token,
+		Compress: *allowCompress,
+		Logf: func(format string, args ...any) {
+			fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+		},
+	}
+	fmt.Printf("🌐 서빙 중: %s → %s\n", *listen, root)
+	if err := srv.ListenAndServe(*listen); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// remoteUnsupported lists options that only apply to local targets
+func remoteUnsupported(opts copyOptions) []string {
+	var names []string
+	if opts.move {
+		names = append(names, "-move")
+	}
+	if opts.linkDest != "" {
+		names = append(names, "-link-dest")
+	}
+	if opts.deltaMB > 0 {
+		names = append(names, "-delta-mb")
+	}
+	if opts.backup.Mode != copier.BackupNone {
+		names = append(names, "-backup")
+	}
+	if len(opts.extraTargets) > 0 {
+		names = append(names, "추가 대상")
+	}
+	return names
+}
+
+// connectRemote dials the server named by a sfc:// location
+func connectRemote(location string, opts copyOptions) (*remote.Client, string, error) {
+	addr, dir, err := remote.ParseURL(location)
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := remote.Dial(addr, opts.remoteToken, opts.compress)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, dir, nil
+}
+
+// pullFiles lists the remote source and downloads it through the copier; it
+// replaces the local scan, so only copier channels are monitored
+func (cm *CopyManager) pullFiles() {
+	cm.wg.Add(1)
+	go cm.monitorCopyProgress()
+	cm.wg.Add(1)
+	go func() {
+		defer cm.wg.Done()
+		for err := range cm.copier.Errors() {
+			cm.onError("복사", err)
+		}
+	}()
+
+	fmt.Println("원격 목록 읽는 중...")
+	entries, err := cm.remote.List(cm.remoteDir)
+	if err != nil {
+		cm.onError("원격", err)
+		cm.mu.Lock()
+		cm.aborted = true
+		cm.mu.Unlock()
+		entries = nil
+	}
+	files := make([]string, 0, len(entries))
+	var totalSize int64
+	for _, e := range entries {
+		files = append(files, filepath.Join(cm.sourceDir, filepath.FromSlash(e.Path)))
+		totalSize += e.Size
+	}
+	cm.copier.SetTransport(cm.remote.Puller(cm.remoteDir, entries))
+	cm.copier.SetTotal(int64(len(files)), totalSize)
+	cm.mu.Lock()
+	cm.copyStarted = true
+	cm.mu.Unlock()
+	fmt.Printf("원격 목록: %d개 파일 (%s). 받기 시작...\n", len(files), formatBytes(totalSize))
+
+	cm.copier.CopyFilesParallel(files)
+	for result := range cm.copier.Results() {
+		cm.reportResult(result)
+	}
+	cm.wg.Wait()
+}
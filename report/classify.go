@@ -0,0 +1,46 @@
+package report
+
+import (
+	"errors"
+	"io/fs"
+	"syscall"
+
+	"superfast-copy-util/copier"
+)
+
+// Error classes used in reports
+const (
+	ClassCanceled   = "canceled"
+	ClassVerify     = "verify-mismatch"
+	ClassNotFound   = "not-found"
+	ClassPermission = "permission"
+	ClassNoSpace    = "no-space"
+	ClassCollision  = "name-collision"
+	ClassIO         = "io"
+	ClassOther      = "other"
+)
+
+// Classify buckets an error so failures can be grouped without parsing
+// the (localized) message; copier errors wrap their cause with %w
+func Classify(err error) string {
+	var errno syscall.Errno
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, copier.ErrCanceled):
+		return ClassCanceled
+	case errors.Is(err, copier.ErrVerifyMismatch):
+		return ClassVerify
+	case errors.Is(err, copier.ErrNameCollision):
+		return ClassCollision
+	case errors.Is(err, fs.ErrNotExist):
+		return ClassNotFound
+	case errors.Is(err, fs.ErrPermission):
+		return ClassPermission
+	case isNoSpace(err):
+		return ClassNoSpace
+	case errors.As(err, &errno):
+		return ClassIO
+	}
+	return ClassOther
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package report
+
+import "syscall"
+
+// errNoSpace is what a write returns on a full disk
+var errNoSpace error = syscall.ENOSPC
@@ -0,0 +1,6 @@
+//go:build windows
+
+package report
+
+// errNoSpace is what a write returns on a full disk
+var errNoSpace error = errorDiskFull
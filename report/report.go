@@ -0,0 +1,356 @@
+// Package report writes a machine-readable record of a copy job: job
+// parameters, one record per copied file and target, and final totals
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"superfast-copy-util/copier"
+)
+
+// Format selects the report file layout
+type Format int
+
+const (
+	// FormatJSONL: 한 줄에 JSON 레코드 하나 (type = job, file, summary)
+	FormatJSONL Format = iota
+	// FormatCSV: 파일별 행, 작업 정보와 합계는 '#' 으로 시작하는 주석 줄
+	FormatCSV
+)
+
+func (f Format) String() string {
+	if f == FormatCSV {
+		return "csv"
+	}
+	return "jsonl"
+}
+
+// ParseFormat converts a flag value; "auto" picks by the file extension
+func ParseFormat(name, file string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		if strings.EqualFold(filepath.Ext(file), ".csv") {
+			return FormatCSV, nil
+		}
+		return FormatJSONL, nil
+	case "jsonl", "json":
+		return FormatJSONL, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return FormatJSONL, fmt.Errorf("알 수 없는 보고서 형식: %s (auto, jsonl, csv)", name)
+}
+
+// Job describes the run the report belongs to
+type Job struct {
+	Source  string            `json:"source"`
+	Targets []string          `json:"targets"`
+	Options map[string]string `json:"options"`
+	Started time.Time         `json:"started"`
+}
+
+// FileRecord is one file copied to one destination
+type FileRecord struct {
+	Type        string  `json:"type"` // "file"
+	Source      string  `json:"source"`
+	Destination string  `json:"destination"`
+	Size        int64   `json:"size"`
+	Outcome     string  `json:"outcome"`
+	ErrorClass  string  `json:"errorClass,omitempty"`
+	Error       string  `json:"error,omitempty"`
+	Digest      string  `json:"sha256,omitempty"`
+	DurationMS  float64 `json:"durationMs"`
+	Backup      string  `json:"backup,omitempty"`
+	Renamed     string  `json:"renamed,omitempty"`   // 대상 이름이 바뀐 이유 (transcode, escape, nfc, collision ...)
+	Collision   string  `json:"collision,omitempty"` // 대상 이름이 겹친 다른 소스 파일
+}
+
+// Summary holds the totals written at the end
+type Summary struct {
+	Type         string           `json:"type"` // "summary"
+	Started      time.Time        `json:"started"`
+	Finished     time.Time        `json:"finished"`
+	Seconds      float64          `json:"seconds"`
+	Files        int64            `json:"files"`
+	Bytes        int64            `json:"bytes"`
+	Failed       int64            `json:"failed"`
+	Skipped      int64            `json:"skipped"`
+	Linked       int64            `json:"linked"`
+	Delta        int64            `json:"delta"`
+	Removed      int64            `json:"removed"`
+	BytesPerSec  float64          `json:"bytesPerSec"`
+	Outcomes     map[string]int64 `json:"outcomes"`
+	ErrorClasses map[string]int64 `json:"errorClasses,omitempty"`
+}
+
+var csvHeader = []string{"source", "destination", "size", "outcome", "error_class", "error", "sha256", "duration_ms", "backup", "renamed", "collision"}
+
+// flushInterval bounds how long records sit in the buffer, so a report of
+// a long-running (watch) job can be followed while it grows
+const flushInterval = time.Second
+
+// Writer appends records to a report file; safe for concurrent use
+type Writer struct {
+	mu        sync.Mutex
+	f         *os.File
+	buf       *bufio.Writer
+	csv       *csv.Writer
+	format    Format
+	job       Job
+	lastFlush time.Time
+	outcomes  map[string]int64
+	classes   map[string]int64
+	err       error
+}
+
+// Create starts a report file and writes the job record
+func Create(file string, format Format, job Job) (*Writer, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, fmt.Errorf("보고서 파일 생성 실패: %w", err)
+	}
+	w := &Writer{
+		f:         f,
+		buf:       bufio.NewWriterSize(f, 256*1024),
+		format:    format,
+		job:       job,
+		lastFlush: time.Now(),
+		outcomes:  make(map[string]int64),
+		classes:   make(map[string]int64),
+	}
+	if format == FormatCSV {
+		w.csv = csv.NewWriter(w.buf)
+		w.comment("job", job)
+		w.csv.Write(csvHeader)
+	} else {
+		w.jsonLine(struct {
+			Type string `json:"type"`
+			Job
+		}{"job", job})
+	}
+	if w.err != nil {
+		f.Close()
+		return nil, w.err
+	}
+	return w, nil
+}
+
+// Add records the outcome of one file (one record per destination)
+func (w *Writer) Add(r copier.CopyResult) {
+	records := w.records(r)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, rec := range records {
+		w.outcomes[rec.Outcome]++
+		if rec.ErrorClass != "" {
+			w.classes[rec.ErrorClass]++
+		}
+		if w.format == FormatCSV {
+			w.csvRow(rec)
+		} else {
+			w.jsonLine(rec)
+		}
+	}
+	if time.Since(w.lastFlush) >= flushInterval {
+		w.flush()
+	}
+}
+
+// Close writes the summary built from the final progress and closes the file
+func (w *Writer) Close(final copier.CopyProgress) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	sum := Summary{
+		Type:         "summary",
+		Started:      w.job.Started,
+		Finished:     now,
+		Seconds:      now.Sub(w.job.Started).Seconds(),
+		Files:        final.CompletedFiles,
+		Bytes:        final.CompletedSize,
+		Failed:       final.FailedFiles,
+		Skipped:      final.SkippedFiles,
+		Linked:       final.LinkedFiles,
+		Delta:        final.DeltaFiles,
+		Removed:      final.RemovedFiles,
+		Outcomes:     w.outcomes,
+		ErrorClasses: w.classes,
+	}
+	if sum.Seconds > 0 {
+		sum.BytesPerSec = float64(sum.Bytes) / sum.Seconds
+	}
+	if w.format == FormatCSV {
+		w.comment("summary", sum)
+	} else {
+		w.jsonLine(sum)
+	}
+	w.flush()
+	if err := w.f.Close(); err != nil && w.err == nil {
+		w.err = fmt.Errorf("보고서 파일 닫기 실패: %w", err)
+	}
+	return w.err
+}
+
+// records expands a result into one record per destination
+func (w *Writer) records(r copier.CopyResult) []FileRecord {
+	base := FileRecord{
+		Type:       "file",
+		Source:     r.FilePath,
+		Size:       r.Size,
+		Outcome:    Outcome(r),
+		Digest:     r.Digest,
+		DurationMS: float64(r.Duration.Microseconds()) / 1000,
+	}
+	if len(r.Targets) == 0 {
+		// 원격 전송 또는 대상 준비 전 실패: 대상 경로를 직접 계산
+		rec := base
+		rec.Destination = w.destination(r.FilePath)
+		setError(&rec, r.Error)
+		return []FileRecord{rec}
+	}
+	records := make([]FileRecord, 0, len(r.Targets))
+	for _, t := range r.Targets {
+		rec := base
+		rec.Destination = t.Path
+		rec.Backup = t.BackupPath
+		rec.Renamed = t.Renamed
+		rec.Collision = t.Collision
+		switch {
+		case t.Error != nil:
+			rec.Outcome = "failed"
+			setError(&rec, t.Error)
+		case !r.Success:
+			// 다른 대상 때문에 실패한 작업 (이동 모드의 소스 삭제 실패 등)
+			setError(&rec, r.Error)
+		case t.Unchanged:
+			rec.Outcome = "skipped"
+		case t.Linked:
+			rec.Outcome = "linked"
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// destination maps a source file onto the first target root
+func (w *Writer) destination(src string) string {
+	if len(w.job.Targets) == 0 {
+		return ""
+	}
+	root := w.job.Targets[0]
+	rel, err := filepath.Rel(w.job.Source, src)
+	if err != nil {
+		return ""
+	}
+	if strings.Contains(root, "://") {
+		return strings.TrimSuffix(root, "/") + "/" + path.Clean(filepath.ToSlash(rel))
+	}
+	return filepath.Join(root, rel)
+}
+
+// Outcome names what happened to a file (copied, skipped, failed, ...)
+func Outcome(r copier.CopyResult) string {
+	switch {
+	case !r.Success && Classify(r.Error) == ClassCanceled:
+		return "canceled"
+	case !r.Success:
+		return "failed"
+	case r.Removed:
+		return "removed"
+	case r.Skipped:
+		return "skipped"
+	case r.Linked:
+		return "linked"
+	case r.Moved:
+		return "moved"
+	case r.Delta:
+		return "delta"
+	}
+	return "copied"
+}
+
+func setError(rec *FileRecord, err error) {
+	if err == nil {
+		return
+	}
+	rec.Error = err.Error()
+	rec.ErrorClass = Classify(err)
+	if rec.ErrorClass == ClassCanceled {
+		rec.Outcome = "canceled"
+	}
+}
+
+func (w *Writer) jsonLine(v any) {
+	if w.err != nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err == nil {
+		b = append(b, '\n')
+		_, err = w.buf.Write(b)
+	}
+	if err != nil {
+		w.err = fmt.Errorf("보고서 쓰기 실패: %w", err)
+	}
+}
+
+func (w *Writer) csvRow(rec FileRecord) {
+	if w.err != nil {
+		return
+	}
+	err := w.csv.Write([]string{
+		rec.Source,
+		rec.Destination,
+		strconv.FormatInt(rec.Size, 10),
+		rec.Outcome,
+		rec.ErrorClass,
+		rec.Error,
+		rec.Digest,
+		strconv.FormatFloat(rec.DurationMS, 'f', 3, 64),
+		rec.Backup,
+		rec.Renamed,
+		rec.Collision,
+	})
+	if err != nil {
+		w.err = fmt.Errorf("보고서 쓰기 실패: %w", err)
+	}
+}
+
+// comment writes "# kind {json}" so CSV readers with a comment character
+// ('#') skip it while the data stays in the same file
+func (w *Writer) comment(kind string, v any) {
+	if w.err != nil {
+		return
+	}
+	w.csv.Flush()
+	b, err := json.Marshal(v)
+	if err == nil {
+		_, err = fmt.Fprintf(w.buf, "# %s %s\n", kind, b)
+	}
+	if err != nil {
+		w.err = fmt.Errorf("보고서 쓰기 실패: %w", err)
+	}
+}
+
+func (w *Writer) flush() {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil && w.err == nil {
+			w.err = fmt.Errorf("보고서 쓰기 실패: %w", err)
+		}
+	}
+	if err := w.buf.Flush(); err != nil && w.err == nil {
+		w.err = fmt.Errorf("보고서 쓰기 실패: %w", err)
+	}
+	w.lastFlush = time.Now()
+}
@@ -0,0 +1,167 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"superfast-copy-util/copier"
+)
+
+var testJob = Job{Source: "/src", Targets: []string{"/dst", "/mirror"}, Options: map[string]string{"verify": "true"}, Started: time.Unix(1700000000, 0)}
+
+// testResults covers each outcome, including a fan-out file where only one
+// destination failed
+func testResults() []copier.CopyResult {
+	return []copier.CopyResult{
+		{FilePath: "/src/a.txt", Success: true, Size: 3, Digest: "abc", Duration: 1500 * time.Microsecond,
+			Targets: []copier.TargetResult{{Path: "/dst/a.txt", Success: true, BackupPath: "/dst/a.txt~"}, {Path: "/mirror/a.txt", Success: true}}},
+		{FilePath: "/src/b.txt", Success: false, Size: 5, Error: fmt.Errorf("쓰기 실패: %w", errNoSpace),
+			Targets: []copier.TargetResult{{Path: "/dst/b.txt", Success: true}, {Path: "/mirror/b.txt", Error: fmt.Errorf("쓰기 실패: %w", errNoSpace)}}},
+		{FilePath: "/src/c.txt", Success: true, Skipped: true,
+			Targets: []copier.TargetResult{{Path: "/dst/c.txt", Success: true, Unchanged: true}, {Path: "/mirror/c.txt", Success: true, Unchanged: true}}},
+		{FilePath: "/src/d/e.txt", Success: false, Error: fmt.Errorf("소스 열기 실패: %w", fs.ErrNotExist)},
+	}
+}
+
+func writeReport(t *testing.T, format Format, name string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	w, err := Create(file, format, testJob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range testResults() {
+		w.Add(r)
+	}
+	if err := w.Close(copier.CopyProgress{CompletedFiles: 2, CompletedSize: 8, FailedFiles: 2, SkippedFiles: 1}); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestJSONLReport(t *testing.T) {
+	f, err := os.Open(writeReport(t, FormatJSONL, "report.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []map[string]any
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var m map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		lines = append(lines, m)
+	}
+	// job + 파일 7개 (대상별) + summary
+	if len(lines) != 9 || lines[0]["type"] != "job" || lines[8]["type"] != "summary" {
+		t.Fatalf("got %d lines: first %v last %v", len(lines), lines[0]["type"], lines[len(lines)-1]["type"])
+	}
+	if lines[0]["source"] != "/src" {
+		t.Errorf("job record %v", lines[0])
+	}
+	want := []struct{ dest, outcome, class string }{
+		{"/dst/a.txt", "copied", ""},
+		{"/mirror/a.txt", "copied", ""},
+		{"/dst/b.txt", "failed", ClassNoSpace},
+		{"/mirror/b.txt", "failed", ClassNoSpace},
+		{"/dst/c.txt", "skipped", ""},
+		{"/mirror/c.txt", "skipped", ""},
+		{"/dst/d/e.txt", "failed", ClassNotFound},
+	}
+	for i, w := range want {
+		rec := lines[i+1]
+		class, _ := rec["errorClass"].(string)
+		if rec["type"] != "file" || rec["destination"] != w.dest || rec["outcome"] != w.outcome || class != w.class {
+			t.Errorf("record %d = %v, want %+v", i, rec, w)
+		}
+	}
+	if lines[1]["sha256"] != "abc" || lines[1]["backup"] != "/dst/a.txt~" || lines[1]["durationMs"] != 1.5 {
+		t.Errorf("details missing from %v", lines[1])
+	}
+	sum := lines[8]
+	outcomes, _ := sum["outcomes"].(map[string]any)
+	if sum["files"] != 2.0 || sum["failed"] != 2.0 || outcomes["failed"] != 3.0 || outcomes["copied"] != 2.0 {
+		t.Errorf("summary %v", sum)
+	}
+}
+
+func TestCSVReport(t *testing.T) {
+	b, err := os.ReadFile(writeReport(t, FormatCSV, "report.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(b)
+	if !strings.HasPrefix(text, "# job {") || !strings.Contains(text, "\n# summary {") {
+		t.Errorf("job/summary comment lines missing:\n%s", text)
+	}
+	r := csv.NewReader(strings.NewReader(text))
+	r.Comment = '#'
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 8 || strings.Join(rows[0], ",") != strings.Join(csvHeader, ",") {
+		t.Fatalf("got %d rows, header %v", len(rows), rows[0])
+	}
+	if got := rows[1]; got[0] != "/src/a.txt" || got[1] != "/dst/a.txt" || got[2] != "3" || got[3] != "copied" || got[6] != "abc" || got[7] != "1.500" || got[8] != "/dst/a.txt~" {
+		t.Errorf("first row %q", got)
+	}
+	if got := rows[4]; got[3] != "failed" || got[4] != ClassNoSpace || got[5] == "" {
+		t.Errorf("failed row %q", got)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name, file string
+		want       Format
+		ok         bool
+	}{
+		{"", "out.csv", FormatCSV, true},
+		{"auto", "out.CSV", FormatCSV, true},
+		{"auto", "out.jsonl", FormatJSONL, true},
+		{"json", "out.csv", FormatJSONL, true},
+		{"csv", "out.txt", FormatCSV, true},
+		{"xml", "out.xml", FormatJSONL, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name, tt.file)
+		if (err == nil) != tt.ok || (tt.ok && got != tt.want) {
+			t.Errorf("ParseFormat(%q, %q) = %v, %v", tt.name, tt.file, got, err)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{fmt.Errorf("x: %w", copier.ErrCanceled), ClassCanceled},
+		{copier.ErrVerifyMismatch, ClassVerify},
+		{fmt.Errorf("x: %w", copier.ErrNameCollision), ClassCollision},
+		{&fs.PathError{Op: "open", Path: "p", Err: fs.ErrNotExist}, ClassNotFound},
+		{&fs.PathError{Op: "open", Path: "p", Err: fs.ErrPermission}, ClassPermission},
+		{fmt.Errorf("x: %w", errNoSpace), ClassNoSpace},
+		{syscall.EIO, ClassIO},
+		{errors.New("무언가 실패"), ClassOther},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Filter selects which entries a scan reports, using shell patterns
+// (path.Match syntax). A pattern without "/" matches the entry name at any
+// depth, one with "/" matches the slash-separated path relative to the scan
+// root, and a trailing "/" limits it to directories. Excluded directories are
+// not descended into; when Include is set only matching files are reported.
+type Filter struct {
+	Include []string
+	Exclude []string
+}
+
+// Validate checks every pattern's syntax
+func (f Filter) Validate() error {
+	for _, list := range [][]string{f.Include, f.Exclude} {
+		for _, p := range list {
+			if _, err := path.Match(strings.TrimSuffix(p, "/"), ""); err != nil {
+				return fmt.Errorf("잘못된 패턴 %q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Empty reports whether the filter lets everything through
+func (f Filter) Empty() bool { return len(f.Include) == 0 && len(f.Exclude) == 0 }
+
+// Match reports whether an entry (path relative to the scan root) is kept
+func (f Filter) Match(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	for _, p := range f.Exclude {
+		if matchPattern(p, rel, isDir) {
+			return false
+		}
+	}
+	if isDir || len(f.Include) == 0 {
+		return true
+	}
+	for _, p := range f.Include {
+		if matchPattern(p, rel, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPath is Match for a path seen outside a scan (e.g. a change event):
+// it also rejects entries below an excluded directory
+func (f Filter) MatchPath(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if !f.Match(dir, true) {
+			return false
+		}
+	}
+	return f.Match(rel, isDir)
+}
+
+func matchPattern(pattern, rel string, isDir bool) bool {
+	if strings.HasSuffix(pattern, "/") {
+		if !isDir {
+			return false
+		}
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	if strings.Contains(pattern, "/") {
+		ok, _ := path.Match(strings.TrimPrefix(pattern, "/"), rel)
+		return ok
+	}
+	ok, _ := path.Match(pattern, path.Base(rel))
+	return ok
+}
+
+// SetFilter restricts what ScanDirectory reports (call before ScanDirectory)
+func (s *Scanner) SetFilter(f Filter) { s.filter = f }
+
+// SetConcurrency overrides the number of directory workers (SCANNER_CONCURRENCY)
+func (s *Scanner) SetConcurrency(n int) {
+	if n > 0 {
+		s.concurrency = n
+	}
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		rel     string
+		isDir   bool
+		want    bool
+		wantDir bool // MatchPath 결과 (상위 디렉터리까지 확인)
+	}{
+		{"empty keeps files", Filter{}, "a/b.txt", false, true, true},
+		{"empty keeps dirs", Filter{}, "a/b", true, true, true},
+
+		{"name pattern at root", Filter{Exclude: []string{"*.tmp"}}, "x.tmp", false, false, false},
+		{"name pattern at depth", Filter{Exclude: []string{"*.tmp"}}, "a/b/x.tmp", false, false, false},
+		{"name pattern other ext", Filter{Exclude: []string{"*.tmp"}}, "a/x.txt", false, true, true},
+		{"name pattern matches dirs too", Filter{Exclude: []string{"build"}}, "a/build", true, false, false},
+
+		{"dir-only pattern skips dir", Filter{Exclude: []string{"cache/"}}, "cache", true, false, false},
+		{"dir-only pattern at depth", Filter{Exclude: []string{"cache/"}}, "a/cache", true, false, false},
+		{"dir-only pattern ignores file", Filter{Exclude: []string{"cache/"}}, "a/cache", false, true, true},
+		{"below excluded dir", Filter{Exclude: []string{"cache/"}}, "cache/deep/f.txt", false, true, false},
+
+		{"path pattern anchored", Filter{Exclude: []string{"logs/*.log"}}, "logs/a.log", false, false, false},
+		{"path pattern not at depth", Filter{Exclude: []string{"logs/*.log"}}, "x/logs/a.log", false, true, true},
+		{"leading slash anchors", Filter{Exclude: []string{"/tmp"}}, "tmp", true, false, false},
+		{"star does not cross slash", Filter{Exclude: []string{"a/*"}}, "a/b/c", false, true, false},
+
+		{"include keeps match", Filter{Include: []string{"*.go"}}, "pkg/x.go", false, true, true},
+		{"include drops others", Filter{Include: []string{"*.go"}}, "pkg/x.md", false, false, false},
+		{"include always descends", Filter{Include: []string{"*.go"}}, "pkg", true, true, true},
+		{"exclude wins over include", Filter{Include: []string{"*.go"}, Exclude: []string{"*_test.go"}}, "x_test.go", false, false, false},
+		{"include under excluded dir", Filter{Include: []string{"*.go"}, Exclude: []string{"vendor/"}}, "vendor/x.go", false, true, false},
+
+		{"character class", Filter{Exclude: []string{"[ab].txt"}}, "b.txt", false, false, false},
+		{"question mark", Filter{Exclude: []string{"?.txt"}}, "ab.txt", false, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.rel, tt.isDir); got != tt.want {
+				t.Errorf("Match(%q, %v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+			}
+			if got := tt.filter.MatchPath(tt.rel, tt.isDir); got != tt.wantDir {
+				t.Errorf("MatchPath(%q, %v) = %v, want %v", tt.rel, tt.isDir, got, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		ok     bool
+	}{
+		{Filter{}, true},
+		{Filter{Include: []string{"*.go", "a/[bc]/"}, Exclude: []string{"cache/", "/tmp"}}, true},
+		{Filter{Include: []string{"[a"}}, false},
+		{Filter{Exclude: []string{"ok", "bad[/"}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.filter.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%+v) = %v, want ok=%v", tt.filter, err, tt.ok)
+		}
+	}
+}
+
+func TestScanDirectoryFilter(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"a.go", "a.md", "sub/b.go", "cache/c.go", "sub/cache/d.go", "sub/x.tmp"} {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewScanner()
+	s.SetFilter(Filter{Include: []string{"*.go", "*.tmp"}, Exclude: []string{"cache/", "*.tmp"}})
+	s.ScanDirectory(root)
+	go func() {
+		for range s.Progress() {
+		}
+	}()
+	go func() {
+		for err := range s.Errors() {
+			t.Errorf("scan error: %v", err)
+		}
+	}()
+	var got []string
+	for f := range s.Files() {
+		rel, err := filepath.Rel(root, f.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+	want := []string{"a.go", "sub/b.go"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("scanned %v, want %v", got, want)
+	}
+}
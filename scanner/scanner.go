@@ -1,6 +1,8 @@
 package scanner
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -8,6 +10,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
+
+	"superfast-copy-util/logging"
+	"superfast-copy-util/metrics"
 )
 
 // Progress represents the scanning progress
@@ -38,6 +44,12 @@ type Scanner struct {
 	totalFiles   int64 // atomic
 	totalSize    int64 // atomic
 	canceled     int32 // atomic flag
+	interrupted  int32 // atomic: 마지막 스캔이 취소로 일찍 끝남
+	filter       Filter
+	log          *slog.Logger
+	pendingDirs  int64            // atomic: 대기열에 있거나 읽는 중인 디렉터리 수
+	scanned      *metrics.Counter // 발견한 파일 수 (SetMetrics 전에는 nil)
+	invalidNames int64            // atomic: UTF-8 이 아닌 파일/디렉터리 이름 수
 }
 
 // NewScanner creates a new Scanner instance
@@ -60,9 +72,31 @@ func NewScanner() *Scanner {
 		startTime:    time.Now(),
 		concurrency:  conc,
 		tickInterval: time.Duration(tickMs) * time.Millisecond,
+		log:          logging.Discard(),
+	}
+}
+
+// SetLogger sends scan events to l; directories are logged at debug level
+func (s *Scanner) SetLogger(l *slog.Logger) {
+	if l != nil {
+		s.log = l
 	}
 }
 
+// SetMetrics registers the scan queue gauges and a found-files counter on r
+func (s *Scanner) SetMetrics(r *metrics.Registry) {
+	if r == nil {
+		return
+	}
+	s.scanned = r.Counter("sfc_scan_files_total", "스캔에서 발견한 파일 수")
+	r.GaugeFunc("sfc_scan_queue_dirs", "스캔 대기열의 디렉터리 수 (읽는 중 포함)", func() float64 {
+		return float64(atomic.LoadInt64(&s.pendingDirs))
+	})
+	r.GaugeFunc("sfc_scan_queue_files", "발견했지만 아직 복사 쪽으로 넘어가지 않은 파일 수", func() float64 {
+		return float64(len(s.filesCh))
+	})
+}
+
 // ScanDirectory starts scanning a directory with parallel workers
 func (s *Scanner) ScanDirectory(path string) {
 	go func() {
@@ -70,6 +104,7 @@ func (s *Scanner) ScanDirectory(path string) {
 
 		// 시작 시간 초기화
 		s.startTime = time.Now()
+		s.log.Info("스캔 시작", "root", path, "workers", s.concurrency)
 
 		// 진행상황 모니터링
 		done := make(chan bool)
@@ -85,6 +120,12 @@ func (s *Scanner) ScanDirectory(path string) {
 		// 디렉터리 대기열 카운팅용 WaitGroup
 		var dirWG sync.WaitGroup
 		dirWG.Add(1) // 루트 디렉터리
+		atomic.StoreInt64(&s.pendingDirs, 1)
+		// 대기열 깊이(메트릭)도 WaitGroup 과 함께 갱신
+		dirDone := func() {
+			atomic.AddInt64(&s.pendingDirs, -1)
+			dirWG.Done()
+		}
 
 		// 모든 디렉터리 처리가 끝나면 안전하게 채널 종료
 		go func() {
@@ -104,16 +145,17 @@ func (s *Scanner) ScanDirectory(path string) {
 				for dir := range dirCh {
 					if atomic.LoadInt32(&s.canceled) == 1 {
 						// 소비만 하고 스킵
-						dirWG.Done()
+						dirDone()
 						continue
 					}
 					entries, err := os.ReadDir(dir)
 					if err != nil {
+						s.log.Warn("디렉터리 읽기 실패", "dir", dir, "error", err)
 						select {
 						case s.errCh <- err:
 						default:
 						}
-						dirWG.Done()
+						dirDone()
 						continue
 					}
 
@@ -122,9 +164,21 @@ func (s *Scanner) ScanDirectory(path string) {
 							break
 						}
 						entryPath := filepath.Join(dir, entry.Name())
+						// 포함/제외 패턴 적용 (제외된 디렉터리는 내려가지 않음)
+						if !s.filter.Empty() {
+							if rel, err := filepath.Rel(path, entryPath); err == nil && !s.filter.Match(rel, entry.IsDir()) {
+								continue
+							}
+						}
+						// 레거시 인코딩(CP949 등) 이름은 대상에서 깨져 보임
+						if !utf8.ValidString(entry.Name()) {
+							atomic.AddInt64(&s.invalidNames, 1)
+							s.log.Debug("UTF-8 이 아닌 이름", "path", fmt.Sprintf("%q", entryPath))
+						}
 						if entry.IsDir() {
 							// 하위 디렉터리 큐잉
 							dirWG.Add(1)
+							atomic.AddInt64(&s.pendingDirs, 1)
 							dirCh <- entryPath
 							continue
 						}
@@ -133,6 +187,7 @@ func (s *Scanner) ScanDirectory(path string) {
 						if collectSize {
 							info, err := os.Lstat(entryPath)
 							if err != nil {
+								s.log.Warn("파일 정보 읽기 실패", "path", entryPath, "error", err)
 								select {
 								case s.errCh <- err:
 								default:
@@ -145,6 +200,7 @@ func (s *Scanner) ScanDirectory(path string) {
 
 						// 진행 상태 O(1) 누적 (atomic)
 						atomic.AddInt64(&s.totalFiles, 1)
+						s.scanned.Inc()
 						if collectSize {
 							atomic.AddInt64(&s.totalSize, fileInfo.Size)
 						}
@@ -156,7 +212,8 @@ func (s *Scanner) ScanDirectory(path string) {
 					}
 
 					// 이 디렉터리 처리 완료
-					dirWG.Done()
+					s.log.Debug("디렉터리 스캔", "dir", dir, "entries", len(entries))
+					dirDone()
 				}
 			}()
 		}
@@ -170,14 +227,30 @@ func (s *Scanner) ScanDirectory(path string) {
 		// 스캔 완료 후 모니터링 중단
 		close(done)
 
+		if atomic.LoadInt32(&s.canceled) == 1 {
+			atomic.StoreInt32(&s.interrupted, 1)
+		}
+
 		// 최종 진행 상황 전송
 		s.sendFinalProgress()
+		s.log.Info("스캔 종료", "files", atomic.LoadInt64(&s.totalFiles),
+			"invalidNames", atomic.LoadInt64(&s.invalidNames),
+			"elapsed", time.Since(s.startTime).Round(time.Millisecond),
+			"canceled", atomic.LoadInt32(&s.canceled) == 1)
 	}()
 }
 
+// InvalidNames returns how many scanned file and directory names are not
+// valid UTF-8 (usually a legacy encoding such as CP949)
+func (s *Scanner) InvalidNames() int64 { return atomic.LoadInt64(&s.invalidNames) }
+
 // Cancel signals the scanner to stop as soon as possible
 func (s *Scanner) Cancel() { atomic.StoreInt32(&s.canceled, 1) }
 
+// Interrupted reports whether the scan was cut short by Cancel, i.e. some
+// source files were never listed
+func (s *Scanner) Interrupted() bool { return atomic.LoadInt32(&s.interrupted) == 1 }
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Names that are not valid UTF-8 are still scanned, and counted
+func TestScanInvalidNames(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{"\xb9\xae\xbc\xad/\xb0\xa1.txt", "\xb9\xae\xbc\xad/ok.txt", "한글.txt"} {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Skip("파일시스템이 UTF-8 이 아닌 이름을 허용하지 않음")
+		}
+		if err := os.WriteFile(p, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewScanner()
+	s.ScanDirectory(root)
+	go func() {
+		for range s.Progress() {
+		}
+	}()
+	go func() {
+		for err := range s.Errors() {
+			t.Errorf("scan error: %v", err)
+		}
+	}()
+	var n int
+	for range s.Files() {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("scanned %d files, want 3", n)
+	}
+	// 디렉터리 하나와 파일 하나
+	if got := s.InvalidNames(); got != 2 {
+		t.Errorf("InvalidNames() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,53 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchPauseSignals maps job-control signals onto copier pause/resume.
+// SIGTSTP(Ctrl+Z) parks the workers and then stops the process as usual,
+// SIGCONT(fg/bg) resumes them, SIGUSR1 toggles pause without stopping.
+// The returned function stops watching.
+func watchPauseSignals(cm *CopyManager) func() {
+	sigCh := make(chan os.Signal, 4)
+	signal.Notify(sigCh, syscall.SIGTSTP, syscall.SIGCONT, syscall.SIGUSR1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-sigCh:
+				switch sig {
+				case syscall.SIGTSTP:
+					cm.Pause()
+					fmt.Print("\n⏸ 일시정지됨 (fg 로 재개)\n")
+					// 워커를 멈춘 뒤 실제 프로세스 정지 (셸로 제어권 반환)
+					_ = syscall.Kill(os.Getpid(), syscall.SIGSTOP)
+				case syscall.SIGCONT:
+					if cm.IsPaused() {
+						cm.Resume()
+						fmt.Print("\n▶ 재개됨\n")
+					}
+				case syscall.SIGUSR1:
+					if cm.IsPaused() {
+						cm.Resume()
+						fmt.Print("\n▶ 재개됨\n")
+					} else {
+						cm.Pause()
+						fmt.Print("\n⏸ 일시정지됨 (SIGUSR1 로 재개)\n")
+					}
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
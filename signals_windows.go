@@ -0,0 +1,6 @@
+//go:build windows
+
+package main
+
+// watchPauseSignals is a no-op on Windows (no job-control signals).
+func watchPauseSignals(cm *CopyManager) func() { return func() {} }
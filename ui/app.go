@@ -29,6 +29,10 @@ func RunTUI() error {
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
+	logger.Info("TUI 시작")
 	_, err := p.Run()
+	if err != nil {
+		logger.Error("TUI 오류", "error", err)
+	}
 	return err
 }
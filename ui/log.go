@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"log/slog"
+
+	"superfast-copy-util/logging"
+)
+
+// logger receives TUI events (default: discarded; the TUI owns the screen,
+// so logs only ever go to a file)
+var logger = logging.Discard()
+
+// childArgs are passed on to the CLI process the TUI starts for a copy
+var childArgs []string
+
+// SetLogger sends TUI events to l; forward holds flags (e.g. -log-file) that
+// the CLI process launched for a copy should receive so it logs alongside
+func SetLogger(l *slog.Logger, forward []string) {
+	if l != nil {
+		logger = l
+	}
+	childArgs = forward
+}
@@ -30,8 +30,6 @@ type Model struct {
 	isCopying  bool
 	sourcePath string
 	targetPath string
-	files      []string
-	totalSize  int64
 	scanProg   scanner.Progress
 	copyProg   copier.CopyProgress
 	lastErr    string
@@ -45,14 +43,11 @@ type Model struct {
 	dialogCursor int    // 0: 예, 1: 아니오
 	page         int    // 0: 홈, 1: 확인, 2: 진행
 	fastMode     bool
+	moveMode     bool // M 키: 복사 대신 이동
 }
 
 // tea messages and cmds for scanning/copying
 type scanProgressMsg struct{ p scanner.Progress }
-type scanFileMsg struct {
-	path string
-	size int64
-}
 type scanErrMsg struct{ err string }
 type scanDoneMsg struct{}
 
@@ -69,14 +64,6 @@ func watchScanProgressCmd(ch <-chan scanner.Progress) tea.Cmd {
 		return scanDoneMsg{}
 	}
 }
-func watchScanFilesCmd(ch <-chan scanner.FileInfo) tea.Cmd {
-	return func() tea.Msg {
-		if f, ok := <-ch; ok {
-			return scanFileMsg{path: f.Path, size: f.Size}
-		}
-		return scanDoneMsg{}
-	}
-}
 func watchScanErrorsCmd(ch <-chan error) tea.Cmd {
 	return func() tea.Msg {
 		if err, ok := <-ch; ok {
@@ -122,7 +109,7 @@ func NewModel() Model {
 		leftPanel:   &leftPanel,
 		rightPanel:  &rightPanel,
 		activePanel: 0,
-		status:      "준비 - Space: 복사, Tab: 패널 전환, Enter: 선택, q: 종료",
+		status:      "준비 - Space: 복사, M: 이동, Tab: 패널 전환, Enter: 선택, q: 종료",
 		drives:      drives,
 	}
 }
@@ -161,6 +148,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.page == 2 {
 			switch msg.String() {
 			case "ctrl+x":
+				logger.Info("TUI 작업 중지", "source", m.sourcePath, "target", m.targetPath)
 				if m.isScanning && m.scn != nil {
 					m.scn.Cancel()
 				}
@@ -173,6 +161,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.page = 0
 				m.status = "중지됨"
 				return m, nil
+			case "p":
+				// 복사 단계에서만 일시정지/재개 (스캔은 대상 아님)
+				if m.isCopying && m.cpr != nil {
+					if m.cpr.IsPaused() {
+						m.cpr.Resume()
+						m.status = "재개됨"
+					} else {
+						m.cpr.Pause()
+						m.status = "일시정지됨"
+					}
+				}
+				return m, nil
 			default:
 				return m, nil
 			}
@@ -197,7 +197,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				src := m.sourcePath
 				dst := m.targetPath
 				createSub := (m.dialogCursor == 0)
-				return m, fastCopyCmd(src, dst, createSub)
+				return m, fastCopyCmd(src, dst, createSub, m.moveMode)
 			case "n", "esc", "q":
 				m.modalActive = false
 				m.page = 0
@@ -214,9 +214,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.activePanel = (m.activePanel + 1) % 2
 			m.leftPanel.focused = (m.activePanel == 0)
 			m.rightPanel.focused = (m.activePanel == 1)
-		case " ":
-			// Space: 다음 페이지(확인)로 이동
+		case " ", "m":
+			// Space: 복사, M: 이동 - 다음 페이지(확인)로 이동
 			if !m.isScanning && !m.isCopying {
+				m.moveMode = msg.String() == "m"
 				m.sourcePath = m.leftPanel.GetCurrentPath()
 				m.targetPath = m.rightPanel.GetCurrentPath()
 				if m.sourcePath != "" && m.targetPath != "" && m.sourcePath != m.targetPath {
@@ -240,22 +241,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.scanProg = msg.p
 		m.status = fmt.Sprintf("스캔 중: %d개 (%.1f 파일/초)", m.scanProg.TotalFiles, m.scanProg.Speed)
 		return m, watchScanProgressCmd(m.scn.Progress())
-	case scanFileMsg:
-		m.files = append(m.files, msg.path)
-		m.totalSize += msg.size
-		return m, watchScanFilesCmd(m.scn.Files())
 	case scanDoneMsg:
-		// begin copy phase
+		// 복사는 스캔과 함께 이미 진행 중: 스캔 상태만 해제
 		m.isScanning = false
-		m.isCopying = true
-		m.status = "복사 준비 중"
-		m.cpr = copier.NewCopier(m.sourcePath, m.targetPath, false)
-		m.cpr.SetTotal(int64(len(m.files)), m.totalSize)
-		m.cpr.CopyFilesParallel(m.files)
-		return m, tea.Batch(
-			watchCopyProgressCmd(m.cpr.Progress()),
-			watchCopyErrorsCmd(m.cpr.Errors()),
-		)
+		return m, nil
 	case scanErrMsg:
 		m.lastErr = msg.err
 		m.status = "스캔 오류 발생"
@@ -267,6 +256,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			percent = float64(m.copyProg.CompletedFiles) * 100 / float64(m.copyProg.TotalFiles)
 		}
 		m.status = fmt.Sprintf("복사 중: %d/%d (%.1f%%)", m.copyProg.CompletedFiles, m.copyProg.TotalFiles, percent)
+		if !m.copyProg.TotalsFinal {
+			m.status += " - 스캔 진행 중"
+		}
 		return m, watchCopyProgressCmd(m.cpr.Progress())
 	case copyDoneMsg:
 		m.isCopying = false
@@ -299,8 +291,12 @@ func (m Model) View() string {
 		} else {
 			no = active.Render("아니오")
 		}
+		heading := "📁 폴더 생성"
+		if m.moveMode {
+			heading = "📦 이동 (원본은 복사 완료 후 삭제됩니다)"
+		}
 		box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("205")).Padding(1, 2).Background(lipgloss.Color("235")).Foreground(lipgloss.Color("15")).Render(
-			lipgloss.JoinVertical(lipgloss.Center, "📁 폴더 생성", "", "폴더를 생성하시겠습니까?", "", lipgloss.JoinHorizontal(lipgloss.Center, yes, no), "", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("← → : 선택, Enter: 확인, Esc: 취소")),
+			lipgloss.JoinVertical(lipgloss.Center, heading, "", "폴더를 생성하시겠습니까?", "", lipgloss.JoinHorizontal(lipgloss.Center, yes, no), "", lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("← → : 선택, Enter: 확인, Esc: 취소")),
 		)
 		body := lipgloss.JoinVertical(lipgloss.Left, title, "", lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box, lipgloss.WithWhitespaceChars(" "), lipgloss.WithWhitespaceForeground(lipgloss.Color("0"))))
 		return body
@@ -310,15 +306,22 @@ func (m Model) View() string {
 	if m.page == 2 {
 		var bodyBuilder strings.Builder
 		if m.fastMode {
-			fmt.Fprintf(&bodyBuilder, "고속 모드 실행 중...\n별도 창에서 CLI로 복사를 수행합니다.")
-		} else if m.isScanning {
+			fmt.Fprintf(&bodyBuilder, "고속 모드 실행 중...\n별도 창에서 CLI로 복사를 수행합니다.\n(그 창에서 P + Enter: 일시정지/재개)")
+		} else if m.isScanning && m.cpr == nil {
 			fmt.Fprintf(&bodyBuilder, "스캔 중\n파일: %d개\n속도: %.1f개/초", m.scanProg.TotalFiles, m.scanProg.Speed)
 		} else {
 			var percent float64
 			if m.copyProg.TotalFiles > 0 {
 				percent = float64(m.copyProg.CompletedFiles) * 100 / float64(m.copyProg.TotalFiles)
 			}
-			fmt.Fprintf(&bodyBuilder, "복사 중\n%d/%d (%.1f%%)\nCtrl+X: 중지", m.copyProg.CompletedFiles, m.copyProg.TotalFiles, percent)
+			label := "복사 중"
+			if m.cpr != nil && m.cpr.IsPaused() {
+				label = "⏸ 일시정지됨"
+			}
+			if m.isScanning {
+				label += " (스캔 진행 중)"
+			}
+			fmt.Fprintf(&bodyBuilder, "%s\n%d/%d (%.1f%%)\nP: 일시정지/재개, Ctrl+X: 중지", label, m.copyProg.CompletedFiles, m.copyProg.TotalFiles, percent)
 		}
 		box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("205")).Padding(1, 2).Background(lipgloss.Color("235")).Foreground(lipgloss.Color("15")).Render(bodyBuilder.String())
 		body := lipgloss.JoinVertical(lipgloss.Left, title, "", lipgloss.Place(m.width, m.height-2, lipgloss.Center, lipgloss.Center, box, lipgloss.WithWhitespaceChars(" "), lipgloss.WithWhitespaceForeground(lipgloss.Color("0"))))
@@ -344,7 +347,7 @@ func (m Model) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, title, "", mainPanel, "", status)
 }
 
-// startScanCopy: 모달 확정 후 스캔→복사 시작
+// startScanCopy: 모달 확정 후 스캔과 복사를 함께 시작 (스캔 결과를 copier가 바로 소비)
 func startScanCopy(m Model, createSub bool) (Model, tea.Cmd) {
 	if createSub {
 		base := filepath.Base(m.sourcePath)
@@ -352,20 +355,31 @@ func startScanCopy(m Model, createSub bool) (Model, tea.Cmd) {
 		_ = os.MkdirAll(m.targetPath, 0755)
 	}
 	m.isScanning = true
+	m.isCopying = true
 	m.status = "진행 중"
-	m.files = nil
-	m.totalSize = 0
+	logger.Info("TUI 복사 시작", "source", m.sourcePath, "target", m.targetPath, "move", m.moveMode)
 	m.scn = scanner.NewScanner()
+	m.scn.SetLogger(logger)
+	m.cpr = copier.NewCopier(m.sourcePath, m.targetPath, false)
+	m.cpr.SetLogger(logger)
+	m.cpr.SetMoveMode(m.moveMode)
+	m.cpr.CopyFromScanner(m.scn.Files())
 	m.scn.ScanDirectory(m.sourcePath)
+	// 결과 채널을 비워 워커가 막히지 않게 함 (실패 수는 진행 상황에 집계됨)
+	go func(results <-chan copier.CopyResult) {
+		for range results {
+		}
+	}(m.cpr.Results())
 	return m, tea.Batch(
 		watchScanProgressCmd(m.scn.Progress()),
-		watchScanFilesCmd(m.scn.Files()),
 		watchScanErrorsCmd(m.scn.Errors()),
+		watchCopyProgressCmd(m.cpr.Progress()),
+		watchCopyErrorsCmd(m.cpr.Errors()),
 	)
 }
 
 // fastCopyCmd runs scan then copy without UI channel round-trips
-func fastCopyCmd(sourcePath, targetPath string, createSub bool) tea.Cmd {
+func fastCopyCmd(sourcePath, targetPath string, createSub, move bool) tea.Cmd {
 	return func() tea.Msg {
 		exe, _ := os.Executable()
 		if createSub {
@@ -374,19 +388,26 @@ func fastCopyCmd(sourcePath, targetPath string, createSub bool) tea.Cmd {
 			_ = os.MkdirAll(targetPath, 0755)
 		}
 		// 새 터미널 창에서 CLI 실행 후, 현재 프로세스 종료
+		// 스캔과 동시에 복사 시작 (목록을 모두 모으기 전에 복사가 시작됨)
+		cliFlags := append([]string{"--cli", "--stream"}, childArgs...)
+		if move {
+			cliFlags = append(cliFlags, "--move")
+		}
+		logger.Info("TUI 에서 CLI 복사 실행", "source", sourcePath, "target", targetPath, "move", move)
 		var cmd *exec.Cmd
 		if runtime.GOOS == "windows" {
-			// start "" <exe> --cli src dst
-			args := append([]string{"/c", "start", "", exe, "--cli", sourcePath, targetPath})
+			// start "" <exe> --cli --stream [--move] src dst
+			args := append([]string{"/c", "start", "", exe}, cliFlags...)
+			args = append(args, sourcePath, targetPath)
 			cmd = exec.Command("cmd", args...)
 		} else if runtime.GOOS == "darwin" {
 			// macOS: 기본 터미널에서 실행 시도
 			script := "osascript"
 			// open Terminal and run command
-			cmd = exec.Command(script, "-e", "tell application \"Terminal\" to do script \""+exe+" --cli '"+sourcePath+"' '"+targetPath+"'\"")
+			cmd = exec.Command(script, "-e", "tell application \"Terminal\" to do script \""+exe+" "+strings.Join(cliFlags, " ")+" '"+sourcePath+"' '"+targetPath+"'\"")
 		} else {
 			// Linux: 백그라운드로 실행 시도 (터미널 매핑 불확실)
-			cmd = exec.Command(exe, "--cli", sourcePath, targetPath)
+			cmd = exec.Command(exe, append(cliFlags, sourcePath, targetPath)...)
 		}
 		_ = cmd.Start()
 		os.Exit(0)
@@ -607,11 +628,11 @@ func (p PanelModel) View() string {
 	b.WriteString("\n\n")
 	help := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	if p.viewMode == 0 {
-		b.WriteString(help.Render("Space: 복사 시작  |  Enter: 위치 선택"))
+		b.WriteString(help.Render("Space: 복사 시작, M: 이동  |  Enter: 위치 선택"))
 	} else {
-		helpText := "Space: 복사 시작  |  Enter: 폴더 이동, '..' 선택: 상위 폴더, Backspace: 위치 목록"
+		helpText := "Space: 복사 시작, M: 이동  |  Enter: 폴더 이동, '..' 선택: 상위 폴더, Backspace: 위치 목록"
 		if p.startedFromShortcut {
-			helpText = "Space: 복사 시작  |  Enter: 폴더 이동, '..' 선택: 첫 화면으로, Backspace: 위치 목록"
+			helpText = "Space: 복사 시작, M: 이동  |  Enter: 폴더 이동, '..' 선택: 첫 화면으로, Backspace: 위치 목록"
 		}
 		b.WriteString(help.Render(helpText))
 	}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"superfast-copy-util/copier"
+	"superfast-copy-util/scanner"
+	"superfast-copy-util/watcher"
+)
+
+// watchOptions controls continuous one-way sync (-watch)
+type watchOptions struct {
+	enabled  bool          // 초기 복사 후 계속 감시하며 변경분 반영
+	deletes  bool          // 소스에서 지워진 항목을 대상에서도 삭제
+	poll     bool          // inotify 대신 폴링
+	debounce time.Duration // 이벤트가 잠잠해질 때까지 기다리는 시간
+	rescan   time.Duration // 전체 재검사 주기 (0=끔)
+}
+
+// registerWatchFlags defines the watch mode flags (CLI only)
+func registerWatchFlags(fs *flag.FlagSet, wo *watchOptions) {
+	fs.BoolVar(&wo.enabled, "watch", false, "초기 복사 후 종료하지 않고 소스 변경을 대상에 계속 반영 (단방향 동기화)")
+	fs.BoolVar(&wo.deletes, "watch-delete", true, "감시 모드에서 소스에서 삭제/이름 변경된 항목을 대상에서도 삭제 (감시 시작 전부터 대상에만 있던 항목은 남김)")
+	fs.BoolVar(&wo.poll, "watch-poll", false, "커널 파일 감시(inotify) 대신 폴링 사용 (네트워크 드라이브 등)")
+	fs.DurationVar(&wo.debounce, "watch-debounce", 500*time.Millisecond, "변경이 이 시간 동안 잠잠해지면 한 번에 반영")
+	fs.DurationVar(&wo.rescan, "rescan", 10*time.Minute, "감시 모드 전체 재검사 주기 (놓친 이벤트 보정, 0=끔)")
+}
+
+// watchUnsupported lists options that do not make sense for a long-running sync
+func watchUnsupported(opts copyOptions, remoteLocation bool) []string {
+	var names []string
+	if opts.move {
+		names = append(names, "-move")
+	}
+	if opts.linkDest != "" {
+		names = append(names, "-link-dest")
+	}
+	if remoteLocation {
+		names = append(names, "원격(sfc://) 위치")
+	}
+	return names
+}
+
+// syncQueue feeds changed paths to the copier without handing the same path
+// to two workers at once; a path that changes while it is being copied is
+// queued again when that copy finishes
+type syncQueue struct {
+	mu       sync.Mutex
+	in       chan scanner.FileInfo
+	out      chan scanner.FileInfo // copier 입력, close 후 닫힘
+	stop     chan struct{}
+	once     sync.Once
+	inflight map[string]bool
+	dirty    map[string]bool
+}
+
+func newSyncQueue() *syncQueue {
+	q := &syncQueue{
+		in:       make(chan scanner.FileInfo, 1024),
+		out:      make(chan scanner.FileInfo),
+		stop:     make(chan struct{}),
+		inflight: make(map[string]bool),
+		dirty:    make(map[string]bool),
+	}
+	go q.pump()
+	return q
+}
+
+// pump moves queued paths to the copier; only it closes out, so pushes that
+// race with close never send on a closed channel
+func (q *syncQueue) pump() {
+	defer close(q.out)
+	for {
+		select {
+		case f := <-q.in:
+			select {
+			case q.out <- f:
+			case <-q.stop:
+				return
+			}
+		case <-q.stop:
+			return
+		}
+	}
+}
+
+// close stops feeding the copier; pending and later pushes are dropped
+func (q *syncQueue) close() {
+	q.once.Do(func() { close(q.stop) })
+}
+
+func (q *syncQueue) push(path string, size int64) {
+	q.mu.Lock()
+	if q.inflight[path] {
+		q.dirty[path] = true
+		q.mu.Unlock()
+		return
+	}
+	q.inflight[path] = true
+	q.mu.Unlock()
+	select {
+	case q.in <- scanner.FileInfo{Path: path, Size: size}:
+	case <-q.stop:
+	}
+}
+
+// done marks a path finished and re-queues it if it changed meanwhile
+func (q *syncQueue) done(path string) {
+	q.mu.Lock()
+	delete(q.inflight, path)
+	again := q.dirty[path]
+	delete(q.dirty, path)
+	q.mu.Unlock()
+	if again {
+		// 결과 처리 고루틴이 큐 대기로 막히지 않도록 별도 고루틴에서 넣음
+		go q.push(path, 0)
+	}
+}
+
+// Watch copies the tree once and then keeps replicating changes until the
+// manager is canceled; every change goes through the same copier workers
+func (cm *CopyManager) Watch(wo watchOptions) error {
+	w, err := watcher.New(cm.sourceDir, watcher.Options{Debounce: wo.debounce, ForcePoll: wo.poll})
+	if err != nil {
+		return fmt.Errorf("감시 시작 실패: %v", err)
+	}
+	defer w.Close()
+	cm.watching = true
+
+	// 이미 같은 파일은 다시 복사하지 않음 (-conflict skip 이면 그대로 둠)
+	if cm.copier.ConflictPolicy() == copier.ConflictOverwrite {
+		cm.copier.SetConflictPolicy(copier.ConflictUpdate)
+	}
+	cm.copier.SetMirrorDeletes(wo.deletes)
+	q := newSyncQueue()
+	cm.copier.CopyFromScanner(q.out)
+
+	go func() {
+		for p := range cm.copier.Progress() {
+			cm.mu.Lock()
+			cm.copyProgress = p
+			cm.mu.Unlock()
+		}
+	}()
+	go func() {
+		for err := range cm.copier.Errors() {
+			cm.onError("복사", err)
+		}
+	}()
+	go func() {
+		for err := range w.Errors() {
+			cm.log.Warn("감시 오류", "error", err)
+			cm.onError("감시", err)
+		}
+	}()
+	resultsDone := make(chan struct{})
+	go func() {
+		defer close(resultsDone)
+		for result := range cm.copier.Results() {
+			q.done(result.FilePath)
+			cm.reportWatchResult(result)
+		}
+	}()
+	// 취소: 진행 중인 파일이 끝나고 결과가 모두 기록될 때까지 기다림
+	stop := func() {
+		q.close()
+		<-resultsDone
+		cm.log.Info("감시 중단")
+	}
+
+	fmt.Printf("👀 감시 시작 (%s). 초기 동기화 중...\n", w.Backend())
+	cm.log.Info("감시 시작", "root", w.Root(), "backend", w.Backend(), "debounce", wo.debounce, "rescan", wo.rescan)
+	if wo.deletes {
+		// 시작 전부터 대상에만 있던 항목은 감시 중에 지워진 것이 아니므로 남김
+		cm.watchKeep = map[string]bool{}
+		if err := cm.copier.WalkExtraneous(func(src string) { cm.watchKeep[src] = true }); err != nil {
+			cm.onError("감시", err)
+		}
+	}
+	cm.fullPass(q, wo.deletes)
+	if cm.stopRequested() {
+		stop()
+		return nil
+	}
+
+	var rescan <-chan time.Time
+	if wo.rescan > 0 {
+		ticker := time.NewTicker(wo.rescan)
+		defer ticker.Stop()
+		rescan = ticker.C
+	}
+	for {
+		select {
+		case <-cm.stopped:
+			stop()
+			return nil
+		case batch, ok := <-w.Batches():
+			if !ok {
+				// 감시 백엔드가 멈춤: 계속 실행하면 변경을 놓치므로 종료
+				stop()
+				return errors.New("파일 감시가 중단되었습니다")
+			}
+			cm.queueBatch(q, w.Root(), batch, wo.deletes)
+		case <-rescan:
+			fmt.Printf("[%s] 🔄 전체 재검사\n", time.Now().Format("15:04:05"))
+			cm.log.Info("주기적 전체 재검사")
+			cm.fullPass(q, wo.deletes)
+		}
+	}
+}
+
+// fullPass queues every source file (unchanged ones are skipped by the
+// copier) and, with deletes on, every target entry missing from the source
+// except those already there when watching started
+func (cm *CopyManager) fullPass(q *syncQueue, deletes bool) {
+	sc := newScanner(cm.opts)
+	go func() {
+		for range sc.Progress() {
+		}
+	}()
+	go func() {
+		for err := range sc.Errors() {
+			cm.onError("스캔", err)
+		}
+	}()
+	sc.ScanDirectory(cm.sourceDir)
+	for f := range sc.Files() {
+		if cm.stopRequested() {
+			// 남은 목록은 버림 (스캐너가 곧 멈춤)
+			sc.Cancel()
+			continue
+		}
+		// 소스에 생긴 파일은 이제 동기화 대상 (나중에 지워지면 삭제 반영)
+		delete(cm.watchKeep, f.Path)
+		q.push(f.Path, f.Size)
+	}
+	if cm.stopRequested() {
+		return
+	}
+	cm.mirrorDirs()
+	if !deletes {
+		return
+	}
+	err := cm.copier.WalkExtraneous(func(src string) {
+		if !cm.watchKeep[src] {
+			q.push(src, 0)
+		}
+	})
+	if err != nil {
+		cm.onError("감시", err)
+	}
+}
+
+// stopRequested reports whether Cancel was called
+func (cm *CopyManager) stopRequested() bool {
+	select {
+	case <-cm.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// mirrorDirs creates source directories (including empty ones) on every target
+func (cm *CopyManager) mirrorDirs() {
+	_ = filepath.WalkDir(cm.sourceDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		// 제외된 디렉터리는 만들지도 내려가지도 않음
+		if rel, rErr := filepath.Rel(cm.sourceDir, p); rErr == nil && rel != "." && !cm.opts.filter.Match(rel, true) {
+			return filepath.SkipDir
+		}
+		cm.mirrorDir(p)
+		return nil
+	})
+}
+
+func (cm *CopyManager) mirrorDir(srcDir string) {
+	rel, err := filepath.Rel(cm.sourceDir, srcDir)
+	if err != nil {
+		return
+	}
+	for _, root := range cm.copier.TargetDirs() {
+		if err := os.MkdirAll(cm.copier.TargetPath(root, rel), 0755); err != nil {
+			cm.onError("감시", fmt.Errorf("디렉터리 생성 실패: %v", err))
+		}
+	}
+}
+
+// queueBatch turns watcher events into copier work based on what is on disk
+// now: files are copied, directories created, missing paths deleted
+func (cm *CopyManager) queueBatch(q *syncQueue, root string, batch []watcher.Event, deletes bool) {
+	cm.log.Debug("변경 배치", "events", len(batch))
+	for _, ev := range batch {
+		if ev.Op == watcher.Rescan {
+			fmt.Printf("[%s] ⚠️  감시 이벤트 유실, 전체 재검사\n", time.Now().Format("15:04:05"))
+			cm.log.Warn("감시 이벤트 유실, 전체 재검사")
+			cm.fullPass(q, deletes)
+			return
+		}
+	}
+	for _, ev := range batch {
+		// 감시기는 절대 경로를 주므로 copier 가 아는 소스 경로로 바꿈
+		rel, err := filepath.Rel(root, ev.Path)
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(cm.sourceDir, rel)
+		info, err := os.Lstat(path)
+		// 제외 패턴에 걸리는 경로는 무시 (사라진 경로는 종류를 모르므로 포함
+		// 패턴이 적용되지 않는 디렉터리 규칙으로 판단)
+		if !cm.opts.filter.MatchPath(rel, err != nil || info.IsDir()) {
+			continue
+		}
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			if deletes {
+				q.push(path, 0)
+			}
+		case err != nil:
+			cm.onError("감시", err)
+		case info.IsDir():
+			cm.mirrorDir(path)
+		case info.Mode().IsRegular():
+			q.push(path, info.Size())
+		}
+	}
+}
+
+// reportWatchResult prints one line per file that actually changed on the target
+func (cm *CopyManager) reportWatchResult(result copier.CopyResult) {
+	cm.reportResult(result)
+	if !result.Success || result.Skipped {
+		return
+	}
+	rel, err := filepath.Rel(cm.sourceDir, result.FilePath)
+	if err != nil {
+		rel = result.FilePath
+	}
+	stamp := time.Now().Format("15:04:05")
+	if result.Removed {
+		fmt.Printf("[%s] 🗑  %s\n", stamp, rel)
+		return
+	}
+	fmt.Printf("[%s] ✔ %s (%s)\n", stamp, rel, formatBytes(result.Size))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond until it holds or the timeout expires
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func fileExists(p string) bool {
+	_, err := os.Lstat(p)
+	return err == nil
+}
+
+// Watch mode mirrors deletes it sees, but never removes target entries that
+// were there before watching started or that the filter excludes
+func TestWatchDeletesOnlyWatchedPaths(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	for _, p := range []string{filepath.Join(src, "a.txt"), filepath.Join(dst, "pre.txt"), filepath.Join(dst, "old", "x.txt")} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(filepath.Base(p)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opts, _, err := parseLayered(t, []string{"-exclude", "*.tmp"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := NewCopyManager(src, dst, opts)
+	done := make(chan error, 1)
+	go func() {
+		done <- cm.Watch(watchOptions{enabled: true, deletes: true, poll: true, debounce: 50 * time.Millisecond, rescan: 100 * time.Millisecond})
+	}()
+	defer func() {
+		cm.Cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch: %v", err)
+		}
+	}()
+
+	waitFor(t, "initial copy", func() bool { return fileExists(filepath.Join(dst, "a.txt")) })
+	// 제외된 파일은 감시 중 대상에 생겨도 삭제하지 않음
+	if err := os.WriteFile(filepath.Join(dst, "scratch.tmp"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(src, "a.txt")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "delete to be mirrored", func() bool { return !fileExists(filepath.Join(dst, "a.txt")) })
+	time.Sleep(300 * time.Millisecond) // 주기적 재검사 몇 번
+
+	for _, rel := range []string{"pre.txt", "old/x.txt", "scratch.tmp"} {
+		if !fileExists(filepath.Join(dst, filepath.FromSlash(rel))) {
+			t.Errorf("%s was deleted from the target", rel)
+		}
+	}
+}
+
+// A path changed while it is being copied is queued once more afterwards,
+// never handed to two workers at the same time
+func TestSyncQueueCoalesces(t *testing.T) {
+	q := newSyncQueue()
+	defer q.close()
+	next := func() string {
+		select {
+		case f := <-q.out:
+			return f.Path
+		case <-time.After(time.Second):
+			return ""
+		}
+	}
+
+	q.push("a", 1)
+	if got := next(); got != "a" {
+		t.Fatalf("first = %q", got)
+	}
+	q.push("a", 1) // 복사 중 변경: 표시만 해 둠
+	q.push("a", 1)
+	q.push("b", 1)
+	if got := next(); got != "b" {
+		t.Fatalf("in-flight path handed out again: %q", got)
+	}
+	q.done("a")
+	if got := next(); got != "a" {
+		t.Fatalf("changed path not re-queued: %q", got)
+	}
+	q.done("a")
+	q.done("b")
+	select {
+	case f := <-q.out:
+		t.Errorf("unexpected %q queued", f.Path)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,187 @@
+//go:build linux
+
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_CLOSE_WRITE | unix.IN_ATTRIB |
+	unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+	unix.IN_DELETE_SELF | unix.IN_ONLYDIR
+
+// inotify tracks one watch descriptor per directory; the kernel has no
+// recursive watch, so new and moved-in directories are added as they appear
+type inotify struct {
+	w     *Watcher
+	fd    int
+	paths map[int]string // wd → 디렉터리 경로
+	wds   map[string]int // 디렉터리 경로 → wd
+}
+
+// startNative sets up inotify for the whole tree up front so a watch limit
+// problem is reported before New returns
+func (w *Watcher) startNative() (func(), error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("inotify 초기화 실패: %v", err)
+	}
+	in := &inotify{w: w, fd: fd, paths: make(map[int]string), wds: make(map[string]int)}
+	if err := in.addTree(w.root, nil); err != nil {
+		unix.Close(fd)
+		if errors.Is(err, unix.ENOSPC) {
+			return nil, fmt.Errorf("inotify 감시 수 한도 초과 (fs.inotify.max_user_watches): %v", err)
+		}
+		return nil, err
+	}
+	return in.run, nil
+}
+
+// addTree watches dir and every directory below it. When found is not nil
+// it also receives each entry seen, so files created before the watch was
+// in place are not missed.
+func (in *inotify) addTree(dir string, found func(string)) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// 그 사이 사라진 디렉터리 등은 무시
+			if p == dir && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			return nil
+		}
+		if found != nil && p != dir {
+			found(p)
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		wd, err := unix.InotifyAddWatch(in.fd, p, inotifyMask)
+		if err != nil {
+			if errors.Is(err, unix.ENOENT) || errors.Is(err, unix.ENOTDIR) {
+				return nil
+			}
+			return fmt.Errorf("감시 추가 실패 %s: %w", p, err)
+		}
+		in.paths[wd] = p
+		in.wds[p] = wd
+		return nil
+	})
+}
+
+// forgetTree drops the watches of a directory moved out of place; the
+// kernel keeps following the inode, so the old paths would be reported
+func (in *inotify) forgetTree(dir string) {
+	prefix := dir + string(filepath.Separator)
+	for p, wd := range in.wds {
+		if p == dir || strings.HasPrefix(p, prefix) {
+			_, _ = unix.InotifyRmWatch(in.fd, uint32(wd))
+			delete(in.wds, p)
+			delete(in.paths, wd)
+		}
+	}
+}
+
+func (in *inotify) run() {
+	defer unix.Close(in.fd)
+	buf := make([]byte, 64*1024)
+	pfd := []unix.PollFd{{Fd: int32(in.fd), Events: unix.POLLIN}}
+	for {
+		select {
+		case <-in.w.done:
+			return
+		default:
+		}
+		// 종료 신호를 확인할 수 있도록 짧게 대기
+		n, err := unix.Poll(pfd, 200)
+		if err != nil && err != unix.EINTR {
+			in.w.sendError(fmt.Errorf("inotify 대기 실패: %v", err))
+			return
+		}
+		if n <= 0 {
+			continue
+		}
+		n, err = unix.Read(in.fd, buf)
+		if err != nil {
+			if err == unix.EAGAIN || err == unix.EINTR {
+				continue
+			}
+			in.w.sendError(fmt.Errorf("inotify 읽기 실패: %v", err))
+			return
+		}
+		if !in.handle(buf[:n]) {
+			return
+		}
+	}
+}
+
+// handle decodes a read buffer; returns false once the watcher is closing
+func (in *inotify) handle(buf []byte) bool {
+	for off := 0; off+unix.SizeofInotifyEvent <= len(buf); {
+		raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[off]))
+		nameStart := off + unix.SizeofInotifyEvent
+		off = nameStart + int(raw.Len)
+		if off > len(buf) {
+			break
+		}
+		name := strings.TrimRight(string(buf[nameStart:off]), "\x00")
+		mask := raw.Mask
+
+		if mask&unix.IN_Q_OVERFLOW != 0 {
+			if !in.w.emit(Event{Path: in.w.root, Op: Rescan}) {
+				return false
+			}
+			continue
+		}
+		dir, ok := in.paths[int(raw.Wd)]
+		if !ok {
+			continue
+		}
+		if mask&unix.IN_IGNORED != 0 {
+			delete(in.paths, int(raw.Wd))
+			if in.wds[dir] == int(raw.Wd) {
+				delete(in.wds, dir)
+			}
+			continue
+		}
+		if name == "" {
+			// 디렉터리 자신에 대한 이벤트 (DELETE_SELF 등): 부모 쪽 이벤트가 처리
+			continue
+		}
+		p := filepath.Join(dir, name)
+		isDir := mask&unix.IN_ISDIR != 0
+
+		switch {
+		case mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0:
+			if isDir {
+				in.forgetTree(p)
+			}
+			if !in.w.emit(Event{Path: p, Op: Remove}) {
+				return false
+			}
+		case isDir && mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+			if !in.w.emit(Event{Path: p, Op: Write}) {
+				return false
+			}
+			// 감시를 붙이기 전에 생긴 하위 항목도 알림
+			err := in.addTree(p, func(child string) { in.w.emit(Event{Path: child, Op: Write}) })
+			if err != nil {
+				in.w.sendError(err)
+				if !in.w.emit(Event{Path: in.w.root, Op: Rescan}) {
+					return false
+				}
+			}
+		default:
+			if !in.w.emit(Event{Path: p, Op: Write}) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,6 @@
+//go:build !linux
+
+package watcher
+
+// startNative is only implemented on Linux (inotify)
+func (w *Watcher) startNative() (func(), error) { return nil, errUnsupported }
@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"io/fs"
+	"path/filepath"
+	"time"
+)
+
+// stamp is what polling compares between two snapshots
+type stamp struct {
+	size  int64
+	mtime int64 // UnixNano
+	mode  fs.FileMode
+}
+
+// snapshot records every entry below root
+func (w *Watcher) snapshot() map[string]stamp {
+	snap := make(map[string]stamp)
+	_ = filepath.WalkDir(w.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == w.root {
+			// 읽을 수 없는 디렉터리는 건너뜀 (다음 주기에 다시 시도)
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		snap[p] = stamp{size: info.Size(), mtime: info.ModTime().UnixNano(), mode: info.Mode().Type()}
+		return nil
+	})
+	return snap
+}
+
+// startPoll returns a loop that diffs snapshots every interval
+func (w *Watcher) startPoll(interval time.Duration) func() {
+	prev := w.snapshot()
+	return func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+			}
+			cur := w.snapshot()
+			for p, s := range cur {
+				if old, ok := prev[p]; !ok || old != s {
+					if !w.emit(Event{Path: p, Op: Write}) {
+						return
+					}
+				}
+			}
+			for p := range prev {
+				if _, ok := cur[p]; !ok {
+					if !w.emit(Event{Path: p, Op: Remove}) {
+						return
+					}
+				}
+			}
+			prev = cur
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Package watcher reports changes below a directory tree in debounced
+// batches, using inotify on Linux and periodic snapshots elsewhere
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Op describes what happened to a path
+type Op uint8
+
+const (
+	// Write: 생성, 수정, 속성 변경, 트리 안으로 이동
+	Write Op = iota + 1
+	// Remove: 삭제 또는 트리 밖(다른 이름)으로 이동
+	Remove
+	// Rescan: 이벤트 유실(큐 넘침 등) - 전체를 다시 비교해야 함
+	Rescan
+)
+
+func (o Op) String() string {
+	switch o {
+	case Write:
+		return "write"
+	case Remove:
+		return "remove"
+	case Rescan:
+		return "rescan"
+	}
+	return fmt.Sprintf("Op(%d)", uint8(o))
+}
+
+// Event is a change to one path (absolute, below the watched root)
+type Event struct {
+	Path string
+	Op   Op
+}
+
+// Options tunes the watcher; zero values select the defaults
+type Options struct {
+	Debounce     time.Duration // 마지막 이벤트 후 이만큼 조용하면 배치 전달 (기본 500ms)
+	MaxDelay     time.Duration // 이벤트가 계속 와도 이 시간 안에는 전달 (기본 5초)
+	PollInterval time.Duration // 폴링 백엔드의 비교 주기 (기본 2초)
+	ForcePoll    bool          // inotify 를 쓸 수 있어도 폴링 사용
+}
+
+const (
+	defaultDebounce     = 500 * time.Millisecond
+	defaultMaxDelay     = 5 * time.Second
+	defaultPollInterval = 2 * time.Second
+)
+
+// errUnsupported is returned by the native backend on platforms without one
+var errUnsupported = errors.New("이 플랫폼은 커널 파일 감시를 지원하지 않음")
+
+// Watcher delivers batches of changes below a root directory
+type Watcher struct {
+	root    string
+	backend string
+	raw     chan Event
+	batches chan []Event
+	errs    chan error
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// New starts watching root. The native backend is used when available;
+// if it cannot start (unsupported platform, watch limit) polling takes over.
+func New(root string, opts Options) (*Watcher, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("디렉터리가 아닙니다: %s", root)
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultDebounce
+	}
+	if opts.MaxDelay < opts.Debounce {
+		opts.MaxDelay = defaultMaxDelay
+		if opts.MaxDelay < opts.Debounce {
+			opts.MaxDelay = opts.Debounce
+		}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+
+	w := &Watcher{
+		root:    root,
+		raw:     make(chan Event, 4096),
+		batches: make(chan []Event, 16),
+		errs:    make(chan error, 100),
+		done:    make(chan struct{}),
+	}
+	var run func()
+	if !opts.ForcePoll {
+		run, err = w.startNative()
+		if err == nil {
+			w.backend = "inotify"
+		} else if !errors.Is(err, errUnsupported) {
+			w.sendError(fmt.Errorf("커널 감시 시작 실패, 폴링으로 대체: %v", err))
+		}
+	}
+	if run == nil {
+		run = w.startPoll(opts.PollInterval)
+		w.backend = fmt.Sprintf("polling (%s)", opts.PollInterval)
+	}
+
+	w.wg.Add(2)
+	go func() {
+		defer w.wg.Done()
+		defer close(w.raw)
+		run()
+	}()
+	go func() {
+		defer w.wg.Done()
+		defer close(w.batches)
+		w.debounce(opts.Debounce, opts.MaxDelay)
+	}()
+	return w, nil
+}
+
+// Root returns the absolute watched directory
+func (w *Watcher) Root() string { return w.root }
+
+// Backend names the mechanism in use ("inotify" or "polling (...)")
+func (w *Watcher) Backend() string { return w.backend }
+
+// Batches returns debounced change batches; each path appears once per
+// batch with its latest Op. A batch holding a Rescan event means events
+// were lost and the whole tree should be compared again.
+func (w *Watcher) Batches() <-chan []Event { return w.batches }
+
+// Errors returns non-fatal watch errors
+func (w *Watcher) Errors() <-chan error { return w.errs }
+
+// Close stops watching and closes the channels
+func (w *Watcher) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+		w.wg.Wait()
+		close(w.errs)
+	})
+	return nil
+}
+
+// emit queues a raw event; returns false once the watcher is closing
+func (w *Watcher) emit(ev Event) bool {
+	select {
+	case w.raw <- ev:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+func (w *Watcher) sendError(err error) {
+	select {
+	case w.errs <- err:
+	default:
+	}
+}
+
+// debounce merges raw events and hands out a batch once the tree has been
+// quiet for quiet, or maxDelay after the first pending event
+func (w *Watcher) debounce(quiet, maxDelay time.Duration) {
+	pending := make(map[string]Op)
+	var order []string
+	rescan := false
+	var first time.Time
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+
+	flush := func() bool {
+		batch := make([]Event, 0, len(order)+1)
+		if rescan {
+			batch = append(batch, Event{Path: w.root, Op: Rescan})
+		}
+		for _, p := range order {
+			batch = append(batch, Event{Path: p, Op: pending[p]})
+		}
+		pending = make(map[string]Op)
+		order = order[:0]
+		rescan = false
+		first = time.Time{}
+		select {
+		case w.batches <- batch:
+			return true
+		case <-w.done:
+			return false
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-w.raw:
+			if !ok {
+				return
+			}
+			if ev.Op == Rescan {
+				rescan = true
+			} else {
+				if _, seen := pending[ev.Path]; !seen {
+					order = append(order, ev.Path)
+				}
+				pending[ev.Path] = ev.Op
+			}
+			now := time.Now()
+			if first.IsZero() {
+				first = now
+			}
+			wait := quiet
+			if left := maxDelay - now.Sub(first); left < wait {
+				wait = left
+			}
+			timer.Reset(wait)
+		case <-timer.C:
+			if first.IsZero() {
+				continue
+			}
+			if !flush() {
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}